@@ -0,0 +1,83 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +kubebuilder:object:generate=true
+package v4
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+)
+
+// +kubebuilder:validation:Enum=PROXY;MESSAGE;
+type ApiType string
+
+const (
+	ProxyType   ApiType = "PROXY"
+	MessageType ApiType = "MESSAGE"
+)
+
+// Api is the model of a v4 API definition, as expected by the APIM v2 Management API.
+// Unlike v2 definitions, v4 definitions rely on listeners, entrypoints and endpoint groups
+// to support both synchronous (proxy) and asynchronous (message) APIs.
+type Api struct {
+	ID          string `json:"id,omitempty"`
+	CrossID     string `json:"crossId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Required
+	Version string `json:"version"`
+	// +kubebuilder:default:=`4.0.0`
+	DefinitionVersion base.DefinitionVersion `json:"definitionVersion,omitempty"`
+	// The definition context is used to inform a management API instance that this API definition
+	// is managed using a kubernetes operator
+	DefinitionContext *DefinitionContext `json:"definitionContext,omitempty"`
+	// +kubebuilder:validation:Required
+	Type ApiType `json:"type"`
+	// +kubebuilder:default:=`STARTED`
+	// +kubebuilder:validation:Enum=STARTED;STOPPED;
+	State string `json:"state,omitempty"`
+	// +kubebuilder:default:=`CREATED`
+	LifecycleState base.LifecycleState `json:"lifecycleState,omitempty"`
+	// +kubebuilder:default:=PRIVATE
+	Visibility base.ApiVisibility `json:"visibility,omitempty"`
+	Tags       []string           `json:"tags,omitempty"`
+	Labels     []string           `json:"labels,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Listeners []*Listener `json:"listeners"`
+	// +kubebuilder:validation:MinItems=1
+	EndpointGroups []*EndpointGroup `json:"endpointGroups"`
+	FlowExecution  *FlowExecution   `json:"flowExecution,omitempty"`
+	Flows          []*Flow          `json:"flows,omitempty"`
+	// A map of plans identified by their name
+	Plans             map[string]*Plan                        `json:"plans,omitempty"`
+	Properties        []*base.Property                        `json:"properties,omitempty"`
+	Metadata          []*base.MetadataEntry                   `json:"metadata,omitempty"`
+	Resources         []*base.ResourceOrRef                   `json:"resources,omitempty"`
+	ResponseTemplates map[string]map[string]*ResponseTemplate `json:"responseTemplates,omitempty"`
+}
+
+type DefinitionContext struct {
+	// +kubebuilder:default:=kubernetes
+	Origin string `json:"origin,omitempty"`
+	// +kubebuilder:default:=fully_managed
+	Mode string `json:"mode,omitempty"`
+}
+
+type ResponseTemplate struct {
+	StatusCode int               `json:"status,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	// +kubebuilder:validation:Optional
+	PropagateErrorKeyToLogs bool `json:"propagateErrorKeyToLogs,omitempty"`
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v4
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/utils"
+)
+
+// +kubebuilder:validation:Enum=ROUND_ROBIN;RANDOM;WEIGHTED_ROUND_ROBIN;WEIGHTED_RANDOM;
+type LoadBalancerType string
+
+type LoadBalancer struct {
+	// +kubebuilder:default:=ROUND_ROBIN
+	Type LoadBalancerType `json:"type,omitempty"`
+}
+
+// EndpointGroup gathers endpoints sharing the same connector type (e.g. http-proxy, kafka, mqtt5)
+// and configuration.
+type EndpointGroup struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	Type                string                  `json:"type"`
+	LoadBalancer        *LoadBalancer           `json:"loadBalancer,omitempty"`
+	SharedConfiguration *utils.GenericStringMap `json:"sharedConfiguration,omitempty"`
+	Endpoints           []*Endpoint             `json:"endpoints,omitempty"`
+	Services            *EndpointGroupServices  `json:"services,omitempty"`
+}
+
+type Endpoint struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	Type   string `json:"type"`
+	Weight int    `json:"weight,omitempty"`
+	// +kubebuilder:default:=true
+	Inherit                     bool                    `json:"inheritConfiguration"`
+	Configuration               *utils.GenericStringMap `json:"configuration,omitempty"`
+	SharedConfigurationOverride *utils.GenericStringMap `json:"sharedConfigurationOverride,omitempty"`
+	Services                    *EndpointServices       `json:"services,omitempty"`
+	Secondary                   bool                    `json:"secondary,omitempty"`
+	Tenants                     []string                `json:"tenants,omitempty"`
+}
+
+type EndpointGroupServices struct {
+	Discovery   *Service `json:"discovery,omitempty"`
+	HealthCheck *Service `json:"healthCheck,omitempty"`
+}
+
+type EndpointServices struct {
+	HealthCheck *Service `json:"healthCheck,omitempty"`
+}
+
+type Service struct {
+	Enabled               bool                    `json:"enabled,omitempty"`
+	OverrideConfiguration bool                    `json:"overrideConfiguration,omitempty"`
+	Type                  string                  `json:"type,omitempty"`
+	Configuration         *utils.GenericStringMap `json:"configuration,omitempty"`
+}
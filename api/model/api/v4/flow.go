@@ -0,0 +1,85 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v4
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/utils"
+)
+
+// +kubebuilder:validation:Enum=DEFAULT;BEST_MATCH;
+type FlowMode string
+
+type FlowExecution struct {
+	// +kubebuilder:default:=DEFAULT
+	Mode          FlowMode `json:"mode,omitempty"`
+	MatchRequired bool     `json:"matchRequired,omitempty"`
+}
+
+// Flow defines the policies executed on each phase of the request processing.
+// The request and response phases apply to all APIs, while the subscribe and publish phases
+// only apply to message APIs.
+type Flow struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// +kubebuilder:default:=true
+	Enabled   bool        `json:"enabled"`
+	Selectors []*Selector `json:"selectors,omitempty"`
+	Request   []*FlowStep `json:"request,omitempty"`
+	Response  []*FlowStep `json:"response,omitempty"`
+	Subscribe []*FlowStep `json:"subscribe,omitempty"`
+	Publish   []*FlowStep `json:"publish,omitempty"`
+	Tags      []string    `json:"tags,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=HTTP;CHANNEL;CONDITION;
+type SelectorType string
+
+// +kubebuilder:validation:Enum=SUBSCRIBE;PUBLISH;
+type ChannelOperation string
+
+// Selector restricts the execution of a flow. HTTP selectors match on path and methods,
+// channel selectors on the message channel and operations and condition selectors on an EL expression.
+type Selector struct {
+	// +kubebuilder:validation:Required
+	Type SelectorType `json:"type"`
+	// For HTTP selectors only
+	Path string `json:"path,omitempty"`
+	// For HTTP selectors only
+	PathOperator base.Operator `json:"pathOperator,omitempty"`
+	// For HTTP selectors only
+	Methods []base.HttpMethod `json:"methods,omitempty"`
+	// For channel selectors only
+	Channel string `json:"channel,omitempty"`
+	// For channel selectors only
+	ChannelOperator base.Operator `json:"channelOperator,omitempty"`
+	// For channel selectors only
+	Operations []ChannelOperation `json:"operations,omitempty"`
+	// For channel selectors only
+	Entrypoints []string `json:"entrypoints,omitempty"`
+	// For condition selectors only
+	Condition string `json:"condition,omitempty"`
+}
+
+type FlowStep struct {
+	// +kubebuilder:default:=true
+	Enabled          bool                    `json:"enabled"`
+	Policy           string                  `json:"policy,omitempty"`
+	Name             string                  `json:"name,omitempty"`
+	Description      string                  `json:"description,omitempty"`
+	Configuration    *utils.GenericStringMap `json:"configuration,omitempty"`
+	Condition        string                  `json:"condition,omitempty"`
+	MessageCondition string                  `json:"messageCondition,omitempty"`
+}
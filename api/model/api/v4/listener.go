@@ -0,0 +1,75 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v4
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/utils"
+)
+
+// +kubebuilder:validation:Enum=HTTP;TCP;SUBSCRIPTION;
+type ListenerType string
+
+const (
+	HttpListenerType         ListenerType = "HTTP"
+	TCPListenerType          ListenerType = "TCP"
+	SubscriptionListenerType ListenerType = "SUBSCRIPTION"
+)
+
+// Listener defines how the API is exposed on the gateway.
+// HTTP listeners expose the API on context paths, TCP listeners on hosts (using SNI)
+// and subscription listeners allow consumers to subscribe to messages pushed by the gateway
+// (e.g. webhooks).
+type Listener struct {
+	// +kubebuilder:validation:Required
+	Type ListenerType `json:"type"`
+	// +kubebuilder:validation:MinItems=1
+	Entrypoints []*Entrypoint `json:"entrypoints"`
+	Servers     []string      `json:"servers,omitempty"`
+	// The paths the API is exposed on, for HTTP listeners only
+	Paths []*Path `json:"paths,omitempty"`
+	// For HTTP listeners only
+	PathMappings []string `json:"pathMappings,omitempty"`
+	// For HTTP listeners only
+	Cors *base.Cors `json:"cors,omitempty"`
+	// The hosts the API is exposed on, for TCP listeners only
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+type Path struct {
+	Host string `json:"host,omitempty"`
+	// +kubebuilder:validation:Required
+	Path           string `json:"path"`
+	OverrideAccess bool   `json:"overrideAccess,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=NONE;AUTO;AT_MOST_ONCE;AT_LEAST_ONCE;
+type QosType string
+
+// Entrypoint is the connector used to consume the API (e.g. http-proxy, sse, webhook, websocket).
+type Entrypoint struct {
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+	// +kubebuilder:default:=AUTO
+	Qos QosType `json:"qos,omitempty"`
+	// The dead letter queue used when messages can not be delivered
+	Dlq           *Dlq                    `json:"dlq,omitempty"`
+	Configuration *utils.GenericStringMap `json:"configuration,omitempty"`
+}
+
+type Dlq struct {
+	// The name of the endpoint messages are sent to
+	Endpoint string `json:"endpoint,omitempty"`
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v4
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/utils"
+)
+
+// +kubebuilder:validation:Enum=STANDARD;PUSH;
+type PlanMode string
+
+type Plan struct {
+	ID      string `json:"id,omitempty"`
+	CrossID string `json:"crossId,omitempty"`
+	// The plan name, defaulting to the key of the plan in the API plans map
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// The security applied to the plan, can be omitted for push plans
+	Security *PlanSecurity `json:"security,omitempty"`
+	// +kubebuilder:default:=STANDARD
+	Mode PlanMode `json:"mode,omitempty"`
+	// +kubebuilder:default:=PUBLISHED
+	Status          base.PlanStatus `json:"status,omitempty"`
+	Characteristics []string        `json:"characteristics,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`
+	// +kubebuilder:default:=AUTO
+	Validation        base.PlanValidation `json:"validation,omitempty"`
+	CommentRequired   bool                `json:"commentRequired,omitempty"`
+	SelectionRule     string              `json:"selectionRule,omitempty"`
+	GeneralConditions string              `json:"generalConditions,omitempty"`
+	Order             int                 `json:"order,omitempty"`
+	// +kubebuilder:default:=API
+	Type           base.PlanType `json:"type,omitempty"`
+	ExcludedGroups []string      `json:"excludedGroups,omitempty"`
+	Flows          []*Flow       `json:"flows,omitempty"`
+}
+
+type PlanSecurity struct {
+	// The security type of the plan (e.g. KEY_LESS, API_KEY, JWT, OAUTH2)
+	// +kubebuilder:validation:Required
+	Type          string                  `json:"type"`
+	Configuration *utils.GenericStringMap `json:"configuration,omitempty"`
+}
@@ -17,6 +17,7 @@ package management
 
 import (
 	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Context struct {
@@ -30,10 +31,39 @@ type Context struct {
 	// +kubebuilder:validation:Required
 	EnvId string `json:"environmentId"`
 	// Auth defines the authentication method used to connect to the API Management.
-	// Can be either basic authentication credentials, a bearer token
-	// or a reference to a kubernetes secret holding one of these two configurations.
+	// Can be either basic authentication credentials, a bearer token,
+	// a reference to a kubernetes secret holding one of these two configurations
+	// or an OAuth2 client credentials configuration.
 	// +kubebuilder:validation:Required
 	Auth *Auth `json:"auth"`
+	// The period at which the resources synced with this context are compared
+	// with their state in the API Management instance to detect drift (e.g. 10m).
+	// Drift detection is disabled if not set.
+	// +kubebuilder:validation:Optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+	// TLS defines the certificates used to verify the management API server
+	// and to authenticate the operator using mutual TLS.
+	// +kubebuilder:validation:Optional
+	TLS *TLS `json:"tls,omitempty"`
+	// RateLimit bounds the requests sent to the management API for this context.
+	// Requests exceeding the limit wait for their turn instead of failing.
+	// +kubebuilder:validation:Optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+}
+
+type RateLimit struct {
+	// The number of requests per second sent to the management API.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+	// The number of requests that can be sent at once above the rate. Defaults to requestsPerSecond.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	Burst int `json:"burst,omitempty"`
+	// The maximum number of requests waiting for a response from the management API.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxInFlight int `json:"maxInFlight,omitempty"`
 }
 
 type Auth struct {
@@ -44,6 +74,43 @@ type Auth struct {
 	Credentials *BasicAuth `json:"credentials,omitempty"`
 	// A secret reference holding either a bearer token or the user name and password used for basic authentication
 	SecretRef *refs.NamespacedName `json:"secretRef,omitempty"`
+	// The OAuth2 client credentials used to get an access token from an identity provider
+	// trusted by the API Management instance.
+	OAuth2 *OAuth2 `json:"oauth2,omitempty"`
+}
+
+type OAuth2 struct {
+	// The URL of the token endpoint of the identity provider.
+	// +kubebuilder:validation:Pattern=`^http(s?):\/\/.+$`
+	// +kubebuilder:validation:Required
+	TokenURL string `json:"tokenUrl"`
+	// +kubebuilder:validation:Required
+	ClientID string `json:"clientId"`
+	// The client secret. Prefer using a secret reference.
+	ClientSecret string `json:"clientSecret,omitempty"`
+	// A secret reference holding the client secret in its clientSecret key.
+	ClientSecretRef *refs.NamespacedName `json:"clientSecretRef,omitempty"`
+	// The scopes requested for the access token.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type TLS struct {
+	// A reference to the CA bundle used to verify the certificate of the management API instance,
+	// in addition to the system trust store.
+	CA *CABundle `json:"ca,omitempty"`
+	// A reference to a kubernetes TLS secret holding the client certificate and key
+	// (tls.crt and tls.key) used for mutual TLS.
+	ClientCertSecretRef *refs.NamespacedName `json:"clientCertSecretRef,omitempty"`
+}
+
+type CABundle struct {
+	// A reference to a secret holding the PEM encoded CA bundle.
+	SecretRef *refs.NamespacedName `json:"secretRef,omitempty"`
+	// A reference to a config map holding the PEM encoded CA bundle.
+	ConfigMapRef *refs.NamespacedName `json:"configMapRef,omitempty"`
+	// The key of the CA bundle in the secret or config map.
+	// +kubebuilder:default:=ca.crt
+	Key string `json:"key,omitempty"`
 }
 
 type BasicAuth struct {
@@ -91,3 +158,47 @@ func (c *Context) SetCredentials(username, password string) {
 		Password: password,
 	}
 }
+
+func (c *Context) HasTLS() bool {
+	return c.TLS != nil
+}
+
+func (c *Context) HasOAuth2() bool {
+	return c.HasAuthentication() && c.Auth.OAuth2 != nil
+}
+
+func (c *Context) SetClientSecret(clientSecret string) {
+	if !c.HasOAuth2() {
+		return
+	}
+
+	c.Auth.OAuth2.ClientSecret = clientSecret
+}
+
+// SecretRefs returns the references of all the secrets used by the context,
+// including authentication and TLS secrets.
+func (c *Context) SecretRefs() []refs.NamespacedName {
+	secretRefs := make([]refs.NamespacedName, 0)
+
+	if c.HasSecretRef() {
+		secretRefs = append(secretRefs, *c.SecretRef())
+	}
+
+	if c.HasOAuth2() && c.Auth.OAuth2.ClientSecretRef != nil {
+		secretRefs = append(secretRefs, *c.Auth.OAuth2.ClientSecretRef)
+	}
+
+	if !c.HasTLS() {
+		return secretRefs
+	}
+
+	if c.TLS.CA != nil && c.TLS.CA.SecretRef != nil {
+		secretRefs = append(secretRefs, *c.TLS.CA.SecretRef)
+	}
+
+	if c.TLS.ClientCertSecretRef != nil {
+		secretRefs = append(secretRefs, *c.TLS.ClientCertSecretRef)
+	}
+
+	return secretRefs
+}
@@ -35,10 +35,16 @@ import (
 type ApiDefinitionSpec struct {
 	v2.Api  `json:",inline"`
 	Context *refs.NamespacedName `json:"contextRef,omitempty"`
+	// The management contexts the API is synced with, e.g. to promote the API across environments.
+	// Can not be used along with contextRef, and requires the API not to be local.
+	// +kubebuilder:validation:Optional
+	Contexts []ContextTarget `json:"contexts,omitempty"`
 	// local defines if the api is local or not.
 	//
-	// If true, the Operator will create the ConfigMaps for the Gateway and pushes the API to the Management API
-	// but without setting the update flag in the datastore.
+	// If true, the Operator will create the ConfigMaps (or the Secrets, depending on its configuration)
+	// for the Gateway and pushes the API to the Management API but without setting the update flag in the datastore.
+	// Definitions exceeding the size of a single object are compressed and, if still too large, split across
+	// several objects, the format being recorded in the definition-format label.
 	//
 	// If false, the Operator will not create the ConfigMaps for the Gateway.
 	// Instead, it pushes the API to the Management API and forces it to update the event in the datastore.
@@ -47,6 +53,18 @@ type ApiDefinitionSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default:=true
 	IsLocal bool `json:"local"`
+	// What to do when the API found in the API Management instance differs from this definition,
+	// which can only be detected if the management context defines a resync period.
+	// Correct (default) re-applies the definition, Report only reports the differences in status and events.
+	// +kubebuilder:default:=Correct
+	// +kubebuilder:validation:Optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+	// What to do with the API in the API Management instance when the API definition is deleted.
+	// Delete closes the plans and deletes the API, Retain leaves the API as is,
+	// and Orphan leaves the API as is but gives back its management to the API Management instance.
+	// Defaults to the deletion policy of the operator.
+	// +kubebuilder:validation:Optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // ApiDefinitionStatus defines the observed state of API Definition.
@@ -54,6 +72,7 @@ type ApiDefinitionStatus struct {
 	OrgID string `json:"organizationId,omitempty"`
 	EnvID string `json:"environmentId,omitempty"`
 	// The ID of the API definition in the Gravitee API Management instance (if an API context has been configured).
+	// For an API synced with multiple contexts, the ID of the API in its primary context, the first one listed.
 	ID      string `json:"id,omitempty"`
 	CrossID string `json:"crossId,omitempty"`
 	// The processing status of the API definition.
@@ -69,6 +88,83 @@ type ApiDefinitionStatus struct {
 	// This field is kept for backward compatibility and shall be removed in future versions.
 	// Use observedGeneration instead.
 	DeprecatedObservedGeneration int64 `json:"generation,omitempty"`
+	// The conditions reflecting the state of the reconciliation of the API definition
+	// (e.g. Ready, Accepted, ResolvedRefs, ContextReachable, Synced, Deployed).
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// The differences found between the API definition and the API in the API Management instance
+	// on the last resync, left as is because of the Report drift policy.
+	Drift []string `json:"drift,omitempty"`
+	// The state of the API in each of the management contexts listed in the spec.
+	Contexts []ContextStatus `json:"contexts,omitempty"`
+	// What would be applied to each management context, reported when the API definition
+	// is annotated with gravitee.io/dry-run.
+	DryRun []DryRunResult `json:"dryRun,omitempty"`
+	// The last deployments of the API in the API Management instance, most recent first.
+	Deployments []DeploymentStatus `json:"deployments,omitempty"`
+	// The hash of the API definition last applied, once its templates and resources resolved.
+	// The API definition is only applied again on resync if it has changed or drifted.
+	AppliedHash string `json:"appliedHash,omitempty"`
+}
+
+// ContextTarget references a management context the API is synced with,
+// along with the values overriding the API definition in that context only.
+type ContextTarget struct {
+	// +kubebuilder:validation:Required
+	ContextRef refs.NamespacedName `json:"contextRef"`
+	Overrides  *ContextOverrides   `json:"overrides,omitempty"`
+}
+
+type ContextOverrides struct {
+	// The state of the API in the context.
+	// +kubebuilder:validation:Enum=STARTED;STOPPED;
+	State string `json:"state,omitempty"`
+	// The tags of the API in the context, replacing the tags of the API definition.
+	Tags []string `json:"tags,omitempty"`
+	// The status of the plans in the context, by plan name.
+	PlanStatus map[string]base.PlanStatus `json:"planStatus,omitempty"`
+	// The targets of the endpoints in the context, by endpoint name.
+	EndpointTargets map[string]string `json:"endpointTargets,omitempty"`
+}
+
+// ContextStatus is the state of the API in one of the management contexts it is synced with.
+type ContextStatus struct {
+	ContextRef refs.NamespacedName `json:"contextRef"`
+	OrgID      string              `json:"organizationId,omitempty"`
+	EnvID      string              `json:"environmentId,omitempty"`
+	// The ID of the API in the context.
+	ID      string `json:"id,omitempty"`
+	CrossID string `json:"crossId,omitempty"`
+	// The state of the API in the context. Can be either STARTED or STOPPED.
+	State string `json:"state,omitempty"`
+	// The processing status of the API in the context.
+	Status ProcessingStatus `json:"processingStatus,omitempty"`
+	// The error that occurred during the last sync with the context, if any.
+	Error string `json:"error,omitempty"`
+	// The last deployments of the API in the context, most recent first.
+	Deployments []DeploymentStatus `json:"deployments,omitempty"`
+}
+
+// DeploymentStatus describes a deployment of the API to the gateways by the API Management instance.
+type DeploymentStatus struct {
+	// The generation of the API definition that has been deployed.
+	Generation int64 `json:"generation"`
+	// The number of the deployment in APIM, unknown if zero.
+	Number int `json:"number,omitempty"`
+	// The label of the deployment in APIM.
+	Label string `json:"label,omitempty"`
+	// The time at which the API has been deployed.
+	Time metav1.Time `json:"time"`
+}
+
+// DryRunResult is what would be applied to a management context if the resource was not annotated for a dry run.
+type DryRunResult struct {
+	ContextRef refs.NamespacedName `json:"contextRef"`
+	// The differences between what would be sent and the entity found in the management API.
+	// The values of templated fields are redacted.
+	Changes []string `json:"changes,omitempty"`
 }
 
 var _ list.Item = &ApiDefinition{}
@@ -98,6 +194,41 @@ const (
 	ProcessingStatusFailed    ProcessingStatus = "Failed"
 )
 
+// +kubebuilder:validation:Enum=Correct;Report;
+type DriftPolicy string
+
+const (
+	DriftPolicyCorrect DriftPolicy = "Correct"
+	DriftPolicyReport  DriftPolicy = "Report"
+)
+
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan;
+type DeletionPolicy string
+
+const (
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// IsDryRun returns true if the resource is annotated for a dry run.
+func IsDryRun(obj metav1.Object) bool {
+	return obj.GetAnnotations()[keys.DryRunAnnotation] == "true"
+}
+
+// IsPaused returns true if the resource is annotated to pause its reconciliation.
+func IsPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[keys.PausedAnnotation] == "true"
+}
+
+// Or returns the deletion policy if set, or the given default policy otherwise.
+func (policy DeletionPolicy) Or(defaultPolicy DeletionPolicy) DeletionPolicy {
+	if policy == "" {
+		return defaultPolicy
+	}
+	return policy
+}
+
 func (api *ApiDefinition) IsMissingDeletionFinalizer() bool {
 	return !kUtil.ContainsFinalizer(api, keys.ApiDefinitionDeletionFinalizer)
 }
@@ -139,6 +270,29 @@ func (api *ApiDefinition) GetOrGenerateCrossID() string {
 	return uuid.FromStrings(api.GetNamespacedName().String())
 }
 
+// ContextRefs returns the references of all the management contexts the API is synced with.
+func (api *ApiDefinition) ContextRefs() []refs.NamespacedName {
+	if api.Spec.Context != nil {
+		return []refs.NamespacedName{*api.Spec.Context}
+	}
+
+	contextRefs := make([]refs.NamespacedName, len(api.Spec.Contexts))
+	for i, target := range api.Spec.Contexts {
+		contextRefs[i] = target.ContextRef
+	}
+	return contextRefs
+}
+
+// ContextStatus returns the status of the API in the given context, if any.
+func (api *ApiDefinition) ContextStatus(ref refs.NamespacedName) *ContextStatus {
+	for i := range api.Status.Contexts {
+		if api.Status.Contexts[i].ContextRef == ref {
+			return &api.Status.Contexts[i]
+		}
+	}
+	return nil
+}
+
 func (api *ApiDefinition) GetNamespacedName() refs.NamespacedName {
 	return refs.NamespacedName{Namespace: api.Namespace, Name: api.Name}
 }
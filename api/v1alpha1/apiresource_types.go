@@ -28,6 +28,12 @@ type ApiResourceSpec struct {
 }
 
 type ApiResourceStatus struct {
+	// The conditions reflecting the state of the reconciliation of the API resource
+	// (e.g. Ready, Accepted).
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 DAVID BRASSELY.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	v4 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v4"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/uuid"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/types/list"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kUtil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The API v4 definition describes APIs using the v4 definition model of Gravitee API Management,
+// supporting both proxy APIs and message APIs (e.g. exposing a Kafka topic over SSE or websockets).
+// +kubebuilder:object:generate=true
+type ApiV4DefinitionSpec struct {
+	v4.Api `json:",inline"`
+	// A reference to the management context the API is pushed to.
+	// Unlike API definitions, v4 APIs can only be synced through a management context.
+	// +kubebuilder:validation:Required
+	Context *refs.NamespacedName `json:"contextRef"`
+}
+
+// ApiV4DefinitionStatus defines the observed state of API v4 Definition.
+type ApiV4DefinitionStatus struct {
+	OrgID string `json:"organizationId,omitempty"`
+	EnvID string `json:"environmentId,omitempty"`
+	// The ID of the API definition in the Gravitee API Management instance.
+	ID      string `json:"id,omitempty"`
+	CrossID string `json:"crossId,omitempty"`
+	// The processing status of the API definition.
+	Status ProcessingStatus `json:"processingStatus,omitempty"`
+	// The state of the API. Can be either STARTED or STOPPED.
+	State string `json:"state,omitempty"`
+	// The IDs of the plans of the API in the Gravitee API Management instance, indexed by plan name.
+	Plans map[string]string `json:"plans,omitempty"`
+	// The errors reported by the Management API while importing the API definition.
+	Errors []string `json:"errors,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The conditions reflecting the state of the reconciliation of the API definition
+	// (e.g. Ready, Accepted, ResolvedRefs, ContextReachable, Synced).
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+var _ list.Item = &ApiV4Definition{}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`,description="API type."
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`,description="API version."
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:resource:shortName=graviteeapisv4
+// ApiV4Definition is the Schema for the apiv4definitions API.
+type ApiV4Definition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApiV4DefinitionSpec   `json:"spec,omitempty"`
+	Status ApiV4DefinitionStatus `json:"status,omitempty"`
+}
+
+func (api *ApiV4Definition) IsMissingDeletionFinalizer() bool {
+	return !kUtil.ContainsFinalizer(api, keys.ApiV4DefinitionDeletionFinalizer)
+}
+
+func (api *ApiV4Definition) IsBeingDeleted() bool {
+	return !api.ObjectMeta.DeletionTimestamp.IsZero()
+}
+
+// PickID returns the ID of the API definition, either from the status if the API
+// is already known, or from the spec if given.
+// An empty ID lets the management API generate one from the cross ID on import.
+func (api *ApiV4Definition) PickID() string {
+	if api.Status.ID != "" {
+		return api.Status.ID
+	}
+
+	return api.Spec.ID
+}
+
+func (api *ApiV4Definition) PickCrossID() string {
+	if api.Status.CrossID != "" {
+		return api.Status.CrossID
+	}
+
+	return api.GetOrGenerateCrossID()
+}
+
+func (api *ApiV4Definition) GetOrGenerateCrossID() string {
+	if api.Spec.CrossID != "" {
+		return api.Spec.CrossID
+	}
+
+	return uuid.FromStrings(api.GetNamespacedName().String())
+}
+
+func (api *ApiV4Definition) GetNamespacedName() refs.NamespacedName {
+	return refs.NamespacedName{Namespace: api.Namespace, Name: api.Name}
+}
+
+func (spec *ApiV4DefinitionSpec) SetDefinitionContext() {
+	spec.DefinitionContext = &v4.DefinitionContext{
+		Mode:   base.ModeFullyManaged,
+		Origin: base.OriginKubernetes,
+	}
+}
+
+var _ list.Interface = &ApiV4DefinitionList{}
+
+// +kubebuilder:object:root=true
+// ApiV4DefinitionList contains a list of ApiV4Definition.
+type ApiV4DefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApiV4Definition `json:"items"`
+}
+
+func (l *ApiV4DefinitionList) GetItems() []list.Item {
+	items := make([]list.Item, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&ApiV4Definition{}, &ApiV4DefinitionList{})
+}
+
+// ContextRefs returns the reference of the management context the API is synced with, if any.
+func (api *ApiV4Definition) ContextRefs() []refs.NamespacedName {
+	if api.Spec.Context == nil {
+		return nil
+	}
+	return []refs.NamespacedName{*api.Spec.Context}
+}
@@ -29,6 +29,18 @@ import (
 type ApplicationSpec struct {
 	application.Application `json:",inline"`
 	Context                 *refs.NamespacedName `json:"contextRef,omitempty"`
+	// What to do when the application found in the API Management instance differs from this application,
+	// which can only be detected if the management context defines a resync period.
+	// Correct (default) re-applies the application, Report only reports the differences in status and events.
+	// +kubebuilder:default:=Correct
+	// +kubebuilder:validation:Optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+	// What to do with the application in the API Management instance when the application is deleted.
+	// Delete deletes the application, Retain leaves the application as is,
+	// and Orphan leaves the application as is but gives back its management to the API Management instance.
+	// Defaults to the deletion policy of the operator.
+	// +kubebuilder:validation:Optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // ApplicationStatus defines the observed state of Application.
@@ -41,6 +53,21 @@ type ApplicationStatus struct {
 	Status ProcessingStatus `json:"processingStatus,omitempty"`
 
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The conditions reflecting the state of the reconciliation of the Application
+	// (e.g. Ready, Accepted, ResolvedRefs, ContextReachable, Synced).
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// The differences found between the application and the application in the API Management instance
+	// on the last resync, left as is because of the Report drift policy.
+	Drift []string `json:"drift,omitempty"`
+	// What would be applied to the management context, reported when the application
+	// is annotated with gravitee.io/dry-run.
+	DryRun []DryRunResult `json:"dryRun,omitempty"`
+	// The hash of the application last applied, once its templates resolved.
+	// The application is only applied again on resync if it has changed or drifted.
+	AppliedHash string `json:"appliedHash,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -75,3 +102,11 @@ func (api *Application) IsBeingDeleted() bool {
 func init() {
 	SchemeBuilder.Register(&Application{}, &ApplicationList{})
 }
+
+// ContextRefs returns the reference of the management context the application is synced with, if any.
+func (app *Application) ContextRefs() []refs.NamespacedName {
+	if app.Spec.Context == nil {
+		return nil
+	}
+	return []refs.NamespacedName{*app.Spec.Context}
+}
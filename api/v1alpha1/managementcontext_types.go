@@ -30,6 +30,20 @@ type ManagementContextSpec struct {
 
 // ManagementContextStatus defines the observed state of an API Context.
 type ManagementContextStatus struct {
+	// The conditions reflecting the state of the reconciliation of the management context
+	// (e.g. Ready, Accepted).
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// The last time the management API of the context was successfully reached
+	// using the credentials of the context.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// The version of Gravitee.io APIM reported by the management API of the context
+	// during the last successful probe.
+	// +optional
+	APIMVersion string `json:"apimVersion,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -37,6 +51,8 @@ type ManagementContextStatus struct {
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.metadata.name`
 // +kubebuilder:printcolumn:name="BaseUrl",type=string,JSONPath=`.spec.baseUrl`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.apimVersion`
+// +kubebuilder:printcolumn:name="Reachable",type=string,JSONPath=`.status.conditions[?(@.type=="ContextReachable")].status`
 // +kubebuilder:resource:shortName=graviteecontexts
 type ManagementContext struct {
 	metav1.TypeMeta   `json:",inline"`
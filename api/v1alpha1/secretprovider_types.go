@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretProviderSpec defines where the values of the provider template function are read from.
+// Exactly one backend must be set. Backends authenticate with a token read from a file mounted
+// in the operator pod, so that no credentials have to be stored in the cluster. The hosts backends
+// can be reached at and the token files are set in the configuration of the operator.
+// +kubebuilder:object:generate=true
+type SecretProviderSpec struct {
+	// Reads values from the KV v2 secrets engine of HashiCorp Vault.
+	// +kubebuilder:validation:Optional
+	Vault *VaultSecretProvider `json:"vault,omitempty"`
+	// Reads values from a JSON object returned by an HTTP endpoint.
+	// +kubebuilder:validation:Optional
+	HTTP *HTTPSecretProvider `json:"http,omitempty"`
+	// How long resolved values are cached, in seconds, when the backend does not return a lease.
+	// Resources using a value are reconciled again when it changes after being refreshed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=300
+	RefreshInterval int `json:"refreshInterval,omitempty"`
+}
+
+type VaultSecretProvider struct {
+	// The address of the Vault server (e.g. https://vault.example.com:8200).
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+	// The Vault namespace, for Vault Enterprise.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	// The mount path of the KV v2 secrets engine.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=secret
+	Mount string `json:"mount,omitempty"`
+	// +kubebuilder:validation:Required
+	Auth VaultKubernetesAuth `json:"auth"`
+}
+
+// VaultKubernetesAuth logs in to Vault with the Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	// The Vault role the operator logs in with.
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+	// The mount path of the Kubernetes auth method.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=kubernetes
+	Mount string `json:"mount,omitempty"`
+}
+
+type HTTPSecretProvider struct {
+	// The base URL of the endpoint, the path given to the provider function is appended to it.
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+	// Headers sent to the endpoint. Must not hold any credentials.
+	// +kubebuilder:validation:Optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SecretProvider declares a backend values can be read from in templates
+// (e.g. [[ provider "my-provider" "path/to/secret" "key" ]]).
+type SecretProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecretProviderSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+type SecretProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretProvider `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SecretProvider{}, &SecretProviderList{})
+}
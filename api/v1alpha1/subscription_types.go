@@ -0,0 +1,138 @@
+/*
+Copyright 2022 DAVID BRASSELY.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kUtil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Subscription allows an Application to consume an API definition through one of its plans.
+// +kubebuilder:object:generate=true
+type SubscriptionSpec struct {
+	// A reference to the API definition to subscribe to.
+	// If no namespace is given, the namespace of the subscription is used.
+	API refs.NamespacedName `json:"apiRef"`
+	// The plan of the API definition the subscription should be attached to.
+	Plan PlanRef `json:"plan"`
+	// A reference to the Application subscribing to the API.
+	// If no namespace is given, the namespace of the subscription is used.
+	App refs.NamespacedName `json:"applicationRef"`
+	// The name of the Secret the API keys of the subscription are written to when subscribing
+	// to an API_KEY plan. The Secret is created in the namespace of the subscription
+	// and defaults to the name of the subscription suffixed with "-api-key".
+	// +kubebuilder:validation:Optional
+	ApiKeySecretName string `json:"apiKeySecretName,omitempty"`
+}
+
+// PlanRef identifies a plan of an API definition either by its name or by its cross ID.
+// If both are set, the cross ID takes precedence.
+type PlanRef struct {
+	Name    string `json:"name,omitempty"`
+	CrossID string `json:"crossId,omitempty"`
+}
+
+// SubscriptionStatus defines the observed state of Subscription.
+type SubscriptionStatus struct {
+	OrgID string `json:"organizationId,omitempty"`
+	EnvID string `json:"environmentId,omitempty"`
+	// The ID of the Subscription in the Gravitee API Management instance.
+	ID string `json:"id,omitempty"`
+	// The ID of the subscribed API in the Gravitee API Management instance.
+	ApiID string `json:"apiId,omitempty"`
+	// The ID of the subscribed plan in the Gravitee API Management instance.
+	PlanID string `json:"planId,omitempty"`
+	// The ID of the subscribing Application in the Gravitee API Management instance.
+	AppID string `json:"applicationId,omitempty"`
+	// The status of the subscription in the Gravitee API Management instance
+	// (e.g. ACCEPTED, PENDING, PAUSED, CLOSED).
+	SubscriptionStatus string `json:"subscriptionStatus,omitempty"`
+	// The name of the Secret holding the API keys of the subscription, if any.
+	ApiKeySecret string `json:"apiKeySecret,omitempty"`
+	// The processing status of the Subscription.
+	Status ProcessingStatus `json:"processingStatus,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The conditions reflecting the state of the reconciliation of the Subscription
+	// (e.g. Ready, Accepted, ResolvedRefs, ContextReachable, Synced).
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="API",type=string,JSONPath=`.spec.apiRef.name`
+// +kubebuilder:printcolumn:name="Application",type=string,JSONPath=`.spec.applicationRef.name`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.subscriptionStatus`
+// +kubebuilder:resource:shortName=graviteesubscriptions
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubscriptionSpec   `json:"spec,omitempty"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subscription `json:"items"`
+}
+
+// ApiRef returns the reference to the subscribed API definition,
+// defaulting to the namespace of the subscription.
+func (sub *Subscription) ApiRef() refs.NamespacedName {
+	return sub.withDefaultNamespace(sub.Spec.API)
+}
+
+// AppRef returns the reference to the subscribing Application,
+// defaulting to the namespace of the subscription.
+func (sub *Subscription) AppRef() refs.NamespacedName {
+	return sub.withDefaultNamespace(sub.Spec.App)
+}
+
+// GetApiKeySecretName returns the name of the Secret holding the API keys of the subscription.
+func (sub *Subscription) GetApiKeySecretName() string {
+	if sub.Spec.ApiKeySecretName != "" {
+		return sub.Spec.ApiKeySecretName
+	}
+	return sub.Name + "-api-key"
+}
+
+func (sub *Subscription) withDefaultNamespace(ref refs.NamespacedName) refs.NamespacedName {
+	if ref.Namespace == "" {
+		ref.Namespace = sub.Namespace
+	}
+	return ref
+}
+
+func (sub *Subscription) IsMissingDeletionFinalizer() bool {
+	return !kUtil.ContainsFinalizer(sub, keys.SubscriptionDeletionFinalizer)
+}
+
+func (sub *Subscription) IsBeingDeleted() bool {
+	return !sub.ObjectMeta.DeletionTimestamp.IsZero()
+}
+
+func init() {
+	SchemeBuilder.Register(&Subscription{}, &SubscriptionList{})
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command export prints the APIs and applications of the APIM environment targeted by a management context
+// as custom resources that adopt them once applied, e.g.
+//
+//	go run ./cmd/export -context default/dev-ctx > adopted.yaml
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/export"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func main() {
+	var contextRef, namespace string
+	var skipApis, skipApplications bool
+	flag.StringVar(&contextRef, "context", "", "The management context to export from, as namespace/name.")
+	flag.StringVar(&namespace, "namespace", "",
+		"The namespace of the exported resources (defaults to the context namespace).")
+	flag.BoolVar(&skipApis, "skip-apis", false, "Do not export APIs.")
+	flag.BoolVar(&skipApplications, "skip-applications", false, "Do not export applications.")
+	flag.Parse()
+
+	if err := run(contextRef, namespace, skipApis, skipApplications); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(contextRef, namespace string, skipApis, skipApplications bool) error {
+	ref, err := parseRef(contextRef)
+	if err != nil {
+		return err
+	}
+
+	if namespace == "" {
+		namespace = ref.Namespace
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gio.AddToScheme(scheme))
+
+	k8s, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	instance, err := apim.FromContextRef(ctx, k8s, ref)
+	if err != nil {
+		return err
+	}
+
+	exporter := export.New(instance, ref, namespace)
+	objs := make([]runtime.Object, 0)
+
+	if !skipApis {
+		apis, apisErr := exporter.APIs()
+		if apisErr != nil {
+			return apisErr
+		}
+		for _, api := range apis {
+			objs = append(objs, api)
+		}
+	}
+
+	if !skipApplications {
+		apps, appsErr := exporter.Applications()
+		if appsErr != nil {
+			return appsErr
+		}
+		for _, app := range apps {
+			objs = append(objs, app)
+		}
+	}
+
+	out, err := export.ToYAML(objs...)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+func parseRef(value string) (refs.NamespacedName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return refs.NamespacedName{}, fmt.Errorf("wrong context %q, expected namespace/name", value)
+	}
+	return refs.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
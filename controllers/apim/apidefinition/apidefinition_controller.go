@@ -22,9 +22,11 @@ import (
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -35,7 +37,11 @@ import (
 	"github.com/go-logr/logr"
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apidefinition/internal"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/metrics"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/pause"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
 )
 
@@ -50,13 +56,21 @@ type Reconciler struct {
 	Watcher  watch.Interface
 }
 
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gravitee.io,resources=secretproviders,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gravitee.io,resources=apidefinitions,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=gravitee.io,resources=apidefinitions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gravitee.io,resources=apidefinitions/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "ApiDefinition", req.NamespacedName)
+	result, err := r.reconcile(ctx, req)
+	span.End(err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	apiDefinition := &gio.ApiDefinition{}
@@ -65,13 +79,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	tracing.SetGeneration(ctx, apiDefinition.Generation)
+
+	events := event.NewRecorder(r.Recorder)
+
+	if paused, err := pause.Check(
+		ctx, r.Client, events, apiDefinition, &apiDefinition.Status.Conditions, apiDefinition.ContextRefs()...,
+	); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Dry runs only report the values of the resource as declared, templates unresolved
+	unresolved := apiDefinition.DeepCopy()
+
 	delegate := internal.NewDelegate(ctx, r.Client, logger)
 	if err := delegate.ResolveTemplate(apiDefinition); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	events := event.NewRecorder(r.Recorder)
-
 	if apiDefinition.GetAnnotations()[keys.IngressTemplateAnnotation] == "true" {
 		logger.Info("syncing template", "template", apiDefinition.Name)
 
@@ -92,6 +117,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if gio.IsDryRun(apiDefinition) && !apiDefinition.IsBeingDeleted() {
+		return dryRun(delegate, events, apiDefinition, unresolved)
+	}
+
 	var reconcileErr error
 
 	if apiDefinition.IsBeingDeleted() {
@@ -100,19 +129,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		})
 	} else {
 		reconcileErr = events.Record(event.Update, apiDefinition, func() error {
-			return delegate.CreateOrUpdate(apiDefinition)
+			return createOrUpdate(delegate, events, apiDefinition)
 		})
 	}
 
 	if reconcileErr == nil {
 		logger.Info("API definition has been reconciled")
-		return ctrl.Result{}, delegate.UpdateStatusSuccess(apiDefinition)
+		return ctrl.Result{RequeueAfter: delegate.ResyncPeriod(apiDefinition)}, delegate.UpdateStatusSuccess(apiDefinition)
 	}
 
-	if err := delegate.UpdateStatusFailure(apiDefinition); err != nil {
+	if err := delegate.UpdateStatusFailure(apiDefinition, reconcileErr); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if retryAfter, open := apim.IsCircuitOpen(reconcileErr); open {
+		logger.Info("APIM is unavailable, requeuing reconcile", "retryAfter", retryAfter)
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
 	if apim.IsRecoverable(reconcileErr) {
 		logger.Error(reconcileErr, "Requeuing reconcile")
 		return ctrl.Result{RequeueAfter: requeueAfterTime}, reconcileErr
@@ -122,11 +156,87 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return ctrl.Result{}, nil
 }
 
+// Differences found with APIM are either corrected by applying the API definition again
+// or only reported, depending on the drift policy of the API definition.
+// The API definition is not applied again if neither it nor APIM has changed since it was last applied.
+func createOrUpdate(delegate *internal.Delegate, events *event.Recorder, apiDefinition *gio.ApiDefinition) error {
+	drift, err := delegate.DetectDrift(apiDefinition)
+	if err != nil {
+		return err
+	}
+
+	correct := apiDefinition.Spec.DriftPolicy != gio.DriftPolicyReport
+	if len(drift) > 0 {
+		events.RecordDrift(apiDefinition, drift, correct)
+		metrics.RecordDrift("ApiDefinition", apiDefinition.Namespace, correct)
+	}
+
+	if len(drift) > 0 && !correct {
+		apiDefinition.Status.Drift = drift
+		return nil
+	}
+
+	hash, err := delegate.AppliedHash(apiDefinition)
+	if err != nil {
+		return err
+	}
+
+	apiDefinition.Status.Drift = nil
+	apiDefinition.Status.DryRun = nil
+
+	// Nothing has changed since the last time the API definition has been applied
+	if len(drift) == 0 && internal.IsApplied(apiDefinition, hash) {
+		return nil
+	}
+
+	if err = delegate.CreateOrUpdate(apiDefinition); err != nil {
+		return err
+	}
+
+	apiDefinition.Status.AppliedHash = hash
+	return nil
+}
+
+// A dry run reports what would be applied to APIM without applying it.
+// The API definition is reconciled again once the dry run annotation is removed.
+func dryRun(
+	delegate *internal.Delegate, events *event.Recorder, apiDefinition, unresolved *gio.ApiDefinition,
+) (ctrl.Result, error) {
+	if err := delegate.DryRun(apiDefinition, unresolved); err != nil {
+		if statusErr := delegate.UpdateStatusFailure(apiDefinition, err); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		if apim.IsRecoverable(err) {
+			return ctrl.Result{RequeueAfter: requeueAfterTime}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	for _, result := range apiDefinition.Status.DryRun {
+		events.RecordDryRun(apiDefinition, result.ContextRef.String(), result.Changes)
+	}
+
+	return ctrl.Result{}, delegate.UpdateStatusDryRun(apiDefinition)
+}
+
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&gio.ApiDefinition{}).
 		Watches(&gio.ManagementContext{}, r.Watcher.WatchContexts(indexer.ContextField)).
 		Watches(&gio.ApiResource{}, r.Watcher.WatchResources()).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
-		Complete(r)
+		Watches(&corev1.ConfigMap{}, r.Watcher.WatchTemplateRefs(indexer.TemplateConfigMapField)).
+		Watches(&corev1.Secret{}, r.Watcher.WatchTemplateRefs(indexer.TemplateSecretField)).
+		Watches(&gio.SecretProvider{}, r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WatchesRawSource(secretprovider.Changes(), r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			watch.TemplateSourceChanged(),
+		))
+
+	if env.Config.NS == "" {
+		builder = builder.Watches(&corev1.Namespace{}, r.Watcher.WatchNamespaces())
+	}
+
+	return builder.Complete(r)
 }
@@ -0,0 +1,72 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+)
+
+// adopt takes ownership of an existing APIM API referenced by the adopt annotation,
+// so that it gets updated instead of being created again along with new plans.
+// The API is only adopted once, as its ID is then kept in the status of the API definition.
+func (d *Delegate) adopt(api *gio.ApiDefinition) error {
+	ref, ok := api.GetAnnotations()[keys.AdoptAnnotation]
+	if !ok || ref == "" || api.Status.ID != "" || !d.HasContext() {
+		return nil
+	}
+
+	mgmtApi, err := d.findApiToAdopt(ref)
+	if errors.IsNotFound(err) {
+		return apim.NewUnrecoverableError(fmt.Errorf("unable to adopt API %s: API not found", ref))
+	}
+	if err != nil {
+		return apim.NewContextError(err)
+	}
+
+	d.log.Info("Adopting existing API", "id", mgmtApi.ID, "crossId", mgmtApi.CrossID)
+
+	if mgmtApi.ShouldSetKubernetesContext() {
+		if err = d.apim.APIs.SetKubernetesContext(mgmtApi.ID); err != nil {
+			return apim.NewContextError(err)
+		}
+	}
+
+	api.Status.ID = mgmtApi.ID
+	if mgmtApi.CrossID != "" {
+		api.Status.CrossID = mgmtApi.CrossID
+	}
+
+	return nil
+}
+
+func (d *Delegate) findApiToAdopt(ref string) (*model.ApiEntity, error) {
+	mgmtApi, err := d.apim.APIs.GetByID(ref)
+	if !errors.IsNotFound(err) {
+		return mgmtApi, err
+	}
+
+	item, err := d.apim.APIs.GetByCrossID(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.apim.APIs.GetByID(item.Id)
+}
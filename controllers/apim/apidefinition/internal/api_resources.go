@@ -17,15 +17,25 @@ package internal
 import (
 	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env/template"
 )
 
 func (d *Delegate) resolveResources(spec *gio.ApiDefinitionSpec) error {
+	return d.setResources(spec, true)
+}
+
+// lookupResources sets the resources referenced by the spec as they are declared, templates unresolved.
+func (d *Delegate) lookupResources(spec *gio.ApiDefinitionSpec) error {
+	return d.setResources(spec, false)
+}
+
+func (d *Delegate) setResources(spec *gio.ApiDefinitionSpec, resolve bool) error {
 	if spec.Resources == nil {
 		return nil
 	}
 
 	for _, resource := range spec.Resources {
-		if err := d.resolveIfRef(resource); err != nil {
+		if err := d.resolveIfRef(resource, resolve); err != nil {
 			return err
 		}
 	}
@@ -33,7 +43,7 @@ func (d *Delegate) resolveResources(spec *gio.ApiDefinitionSpec) error {
 	return nil
 }
 
-func (d *Delegate) resolveIfRef(resourceOrRef *base.ResourceOrRef) error {
+func (d *Delegate) resolveIfRef(resourceOrRef *base.ResourceOrRef, resolve bool) error {
 	if !resourceOrRef.IsRef() {
 		return nil
 	}
@@ -47,6 +57,12 @@ func (d *Delegate) resolveIfRef(resourceOrRef *base.ResourceOrRef) error {
 		return err
 	}
 
+	if resolve {
+		if err := template.NewResolver(d.ctx, d.k8s, d.log, resource).Resolve(); err != nil {
+			return err
+		}
+	}
+
 	resourceOrRef.Resource = resource.Spec.Resource
 
 	return nil
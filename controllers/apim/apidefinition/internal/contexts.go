@@ -0,0 +1,201 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/uuid"
+	kErrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// createOrUpdateContexts syncs the API with each of the contexts listed in the spec.
+// All the contexts are synced even if one of them fails, and the status of each context is reported separately.
+// The cross ID of the API and of its plans are the same in all contexts, so that promoting
+// the API from one environment to another keeps its identity.
+//
+// The API is removed from the contexts that are not listed anymore according to its deletion policy,
+// and the first context listed is the primary context of the API, whose ID is reported in the status.
+func (d *Delegate) createOrUpdateContexts(apiDefinition *gio.ApiDefinition) error {
+	if apiDefinition.Spec.IsLocal {
+		return apim.NewUnrecoverableError(fmt.Errorf("an API synced with multiple contexts can not be local"))
+	}
+
+	cp := apiDefinition.DeepCopy()
+	spec := &cp.Spec
+	spec.ID = baseID(cp)
+	spec.CrossID = cp.PickCrossID()
+
+	if err := d.resolveResources(spec); err != nil {
+		d.log.Error(err, "unable to resolve resources")
+		return err
+	}
+
+	generateEmptyPlanCrossIds(spec)
+
+	statuses := make([]gio.ContextStatus, 0, len(spec.Contexts))
+	errs := make([]error, 0)
+
+	for _, target := range spec.Contexts {
+		status := gio.ContextStatus{ContextRef: target.ContextRef}
+		if previous := apiDefinition.ContextStatus(target.ContextRef); previous != nil {
+			status = *previous
+		}
+
+		if err := d.syncContext(cp, target, &status); err != nil {
+			d.log.Error(err, "Unable to sync API with context", "context", target.ContextRef.String())
+			status.Status = gio.ProcessingStatusFailed
+			status.Error = err.Error()
+			errs = append(errs, err)
+		} else {
+			status.Status = gio.ProcessingStatusCompleted
+			status.Error = ""
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	// Removed contexts are kept in status until the API has been removed from them
+	removed := removedContexts(apiDefinition)
+	if err := d.deleteWithContexts(apiDefinition, removed); err != nil {
+		statuses = append(statuses, removed...)
+		errs = append(errs, err)
+	}
+
+	apiDefinition.Status.Contexts = statuses
+	apiDefinition.Status.CrossID = spec.CrossID
+
+	if primary := apiDefinition.ContextStatus(spec.Contexts[0].ContextRef); primary != nil {
+		apiDefinition.Status.ID = primary.ID
+		apiDefinition.Status.OrgID = primary.OrgID
+		apiDefinition.Status.EnvID = primary.EnvID
+	}
+
+	if len(errs) > 0 {
+		return kErrors.NewAggregate(errs)
+	}
+
+	apiDefinition.Status.Status = gio.ProcessingStatusCompleted
+	return nil
+}
+
+func (d *Delegate) syncContext(api *gio.ApiDefinition, target gio.ContextTarget, status *gio.ContextStatus) error {
+	instance, err := apim.FromContextRef(d.ctx, d.k8s, target.ContextRef)
+	if err != nil {
+		return err
+	}
+
+	delegate := &Delegate{ctx: d.ctx, k8s: d.k8s, log: d.log, apim: instance}
+
+	cp := api.DeepCopy()
+	spec := &cp.Spec
+	spec.ID = contextID(api, target.ContextRef, status)
+	cp.Status.ID = status.ID
+	applyOverrides(spec, target.Overrides)
+
+	stateUpdated := status.State != spec.State
+	status.OrgID = instance.OrgID()
+	status.EnvID = instance.EnvID()
+	status.CrossID = spec.CrossID
+
+	if err = delegate.updateWithContext(cp); err != nil {
+		return err
+	}
+
+	status.ID = spec.ID
+
+	if err = delegate.deploy(cp, &status.Deployments); err != nil {
+		return err
+	}
+
+	if stateUpdated {
+		if err = delegate.updateState(cp); err != nil {
+			return err
+		}
+	}
+
+	status.State = spec.State
+
+	return nil
+}
+
+// removedContexts returns the status of the contexts the API has been synced with that are not listed anymore.
+func removedContexts(api *gio.ApiDefinition) []gio.ContextStatus {
+	removed := make([]gio.ContextStatus, 0)
+	for _, status := range api.Status.Contexts {
+		if !slices.ContainsFunc(api.Spec.Contexts, func(target gio.ContextTarget) bool {
+			return target.ContextRef == status.ContextRef
+		}) {
+			removed = append(removed, status)
+		}
+	}
+	return removed
+}
+
+// The IDs of the API in each context derive from the ID of the API definition,
+// the ID found in status being the one of its primary context.
+func baseID(api *gio.ApiDefinition) string {
+	if api.Spec.ID != "" {
+		return api.Spec.ID
+	}
+	return string(api.UID)
+}
+
+// API IDs are unique across the environments of an APIM instance, unlike cross IDs.
+func contextID(api *gio.ApiDefinition, ref refs.NamespacedName, status *gio.ContextStatus) string {
+	if status.ID != "" {
+		return status.ID
+	}
+	return uuid.FromStrings(api.Spec.ID, separator, ref.String())
+}
+
+func applyOverrides(spec *gio.ApiDefinitionSpec, overrides *gio.ContextOverrides) {
+	if overrides == nil {
+		return
+	}
+
+	if overrides.State != "" {
+		spec.State = overrides.State
+	}
+
+	if overrides.Tags != nil {
+		spec.Tags = overrides.Tags
+	}
+
+	for _, plan := range spec.Plans {
+		if plan.Plan == nil {
+			continue
+		}
+		if status, ok := overrides.PlanStatus[plan.Name]; ok {
+			plan.Status = status
+		}
+	}
+
+	if spec.Proxy == nil {
+		return
+	}
+
+	for _, group := range spec.Proxy.Groups {
+		for _, endpoint := range group.Endpoints {
+			if target, ok := overrides.EndpointTargets[endpoint.Name]; ok {
+				endpoint.Target = target
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	v2 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v2"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+)
+
+func newContextsApi() *gio.ApiDefinition {
+	api := newDriftApi()
+	api.Spec.Tags = []string{"internal"}
+	api.Spec.Proxy = &v2.Proxy{
+		Groups: []*v2.EndpointGroup{
+			{
+				Name: "default",
+				Endpoints: []*v2.Endpoint{
+					{Name: "backend", Target: "https://api.dev.example.com"},
+					{Name: "backup", Target: "https://backup.dev.example.com"},
+				},
+			},
+		},
+	}
+	return api
+}
+
+var _ = Describe("Context overrides", func() {
+	It("Should keep the API unchanged without overrides", func() {
+		api := newContextsApi()
+		expected := api.DeepCopy()
+		applyOverrides(&api.Spec, nil)
+		Expect(api.Spec).To(Equal(expected.Spec))
+	})
+
+	It("Should apply the overrides of the context", func() {
+		api := newContextsApi()
+		applyOverrides(&api.Spec, &gio.ContextOverrides{
+			State:           base.StateStopped,
+			Tags:            []string{"public"},
+			PlanStatus:      map[string]base.PlanStatus{"free": base.StagingPlanStatus},
+			EndpointTargets: map[string]string{"backend": "https://api.example.com"},
+		})
+
+		Expect(api.Spec.State).To(Equal(base.StateStopped))
+		Expect(api.Spec.Tags).To(Equal([]string{"public"}))
+		Expect(api.Spec.Plans[0].Status).To(Equal(base.StagingPlanStatus))
+
+		endpoints := api.Spec.Proxy.Groups[0].Endpoints
+		Expect(endpoints[0].Target).To(Equal("https://api.example.com"))
+		Expect(endpoints[1].Target).To(Equal("https://backup.dev.example.com"))
+	})
+	It("Should find the contexts the API is not synced with anymore", func() {
+		dev, prod := refs.NewNamespacedName("default", "dev"), refs.NewNamespacedName("default", "prod")
+		api := newContextsApi()
+		api.Spec.Contexts = []gio.ContextTarget{{ContextRef: prod}}
+		api.Status.Contexts = []gio.ContextStatus{{ContextRef: dev, ID: "dev-id"}, {ContextRef: prod, ID: "prod-id"}}
+
+		Expect(removedContexts(api)).To(Equal([]gio.ContextStatus{{ContextRef: dev, ID: "dev-id"}}))
+	})
+
+	It("Should derive context IDs from the API definition rather than from its primary context", func() {
+		api := newContextsApi()
+		api.UID = "uid"
+		api.Status.ID = "primary-id"
+		Expect(baseID(api)).To(Equal("uid"))
+
+		api.Spec.ID = "spec-id"
+		Expect(baseID(api)).To(Equal("spec-id"))
+	})
+})
@@ -0,0 +1,128 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Definitions are written as is when they fit in a single object. Larger definitions are
+// compressed and base64 encoded, and split across several objects when they still do not fit,
+// the object named after the API then holding the ordered list of its parts. Parts are named
+// after the checksum of the definition, so that the parts of a previous definition are never
+// overwritten while a gateway may still be reading them.
+const (
+	formatKey             = "definition-format"
+	partOfKey             = "definition-part-of"
+	definitionPartsKey    = "definitionParts"
+	definitionChecksumKey = "definitionChecksum"
+
+	formatJSON    = "json"
+	formatGzip    = "gzip"
+	formatSharded = "gzip-sharded"
+
+	maxDefinitionParts = 10
+	partChecksumLength = 10
+)
+
+// Config maps are subject to the same size limit as secrets.
+var maxDataSize = v1.MaxSecretSize
+
+// encodeDefinition adds the definition of the API to data using the first format it fits in,
+// returning the format and, for sharded definitions, the content of each part.
+func encodeDefinition(name string, definition []byte, data map[string]string) (string, []string, error) {
+	data[definitionKey] = string(definition)
+	if dataSize(data) <= maxDataSize {
+		return formatJSON, nil, nil
+	}
+
+	compressed, err := compress(definition)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data[definitionKey] = compressed
+	if dataSize(data) <= maxDataSize {
+		return formatGzip, nil, nil
+	}
+
+	delete(data, definitionKey)
+	parts := split(compressed, maxDataSize)
+	if len(parts) > maxDefinitionParts {
+		return "", nil, conditions.NewError(
+			conditions.Deployed, conditions.ReasonTooLarge,
+			fmt.Errorf(
+				"definition is %d bytes (%d bytes compressed) and can not be split in less than %d parts of %d bytes",
+				len(definition), len(compressed), maxDefinitionParts, maxDataSize,
+			),
+		)
+	}
+
+	sum := sha256.Sum256([]byte(compressed))
+	checksum := hex.EncodeToString(sum[:])
+
+	names := make([]string, 0, len(parts))
+	for i := range parts {
+		names = append(names, partName(name, checksum, i))
+	}
+
+	data[definitionPartsKey] = strings.Join(names, ",")
+	data[definitionChecksumKey] = checksum
+
+	return formatSharded, parts, nil
+}
+
+func compress(definition []byte) (string, error) {
+	buf := &bytes.Buffer{}
+	writer := gzip.NewWriter(buf)
+	if _, err := writer.Write(definition); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func split(value string, size int) []string {
+	parts := make([]string, 0, len(value)/size+1)
+	for len(value) > size {
+		parts = append(parts, value[:size])
+		value = value[size:]
+	}
+	return append(parts, value)
+}
+
+func partName(name, checksum string, index int) string {
+	return fmt.Sprintf("%s-part-%s-%d", name, checksum[:partChecksumLength], index+1)
+}
+
+// dataSize computes the size of data the same way the API server does when enforcing its limit.
+func dataSize(data map[string]string) int {
+	size := 0
+	for _, value := range data {
+		size += len(value)
+	}
+	return size
+}
@@ -22,18 +22,11 @@ import (
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env/template"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
-	coreV1 "k8s.io/api/core/v1"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-const (
-	separator = "/"
-
-	bearerTokenSecretKey = "bearerToken"
-	usernameSecretKey    = "username"
-	passwordSecretKey    = "password"
-)
+const separator = "/"
 
 type Delegate struct {
 	ctx  context.Context
@@ -53,22 +46,11 @@ func (d *Delegate) ResolveTemplate(api *gio.ApiDefinition) error {
 }
 
 func (d *Delegate) ResolveContext(api *gio.ApiDefinition) error {
-	managementContext := new(gio.ManagementContext)
-
 	ref := api.Spec.Context
-	ns := ref.ToK8sType()
 
 	d.log.Info("Resolving API context", "namespace", ref.Namespace, "name", ref.Name)
 
-	if err := d.k8s.Get(d.ctx, ns, managementContext); err != nil {
-		return err
-	}
-
-	if err := d.resolveContextSecrets(managementContext); err != nil {
-		return err
-	}
-
-	apim, err := apim.FromContext(d.ctx, managementContext.Spec.Context)
+	apim, err := apim.FromContextRef(d.ctx, d.k8s, *ref)
 	if err != nil {
 		return err
 	}
@@ -83,41 +65,11 @@ func (d *Delegate) HasContext() bool {
 
 func (d *Delegate) AddDeletionFinalizer(api *gio.ApiDefinition) {
 	if api.IsMissingDeletionFinalizer() {
+		// Patching only the finalizer keeps the templates of the API definition in the cluster
+		base := api.DeepCopy()
 		util.AddFinalizer(api, keys.ApiDefinitionDeletionFinalizer)
-		if err := d.k8s.Update(d.ctx, api); err != nil {
+		if err := d.k8s.Patch(d.ctx, api, k8s.MergeFrom(base)); err != nil {
 			d.log.Error(err, "Unable to add deletion finalizer to API definition")
 		}
 	}
 }
-
-func (d *Delegate) resolveContextSecrets(context *gio.ManagementContext) error {
-	management := context.Spec
-
-	if management.HasSecretRef() {
-		secret := new(coreV1.Secret)
-
-		secretKey := management.SecretRef().ToK8sType()
-		secretKey.Namespace = getSecretNamespace(context)
-
-		if err := d.k8s.Get(d.ctx, secretKey, secret); err != nil {
-			return err
-		}
-
-		bearerToken := string(secret.Data[bearerTokenSecretKey])
-		username := string(secret.Data[usernameSecretKey])
-		password := string(secret.Data[passwordSecretKey])
-
-		management.SetToken(bearerToken)
-		management.SetCredentials(username, password)
-	}
-
-	return nil
-}
-
-func getSecretNamespace(context *gio.ManagementContext) string {
-	secretRef := context.Spec.SecretRef()
-	if secretRef.Namespace != "" {
-		return secretRef.Namespace
-	}
-	return context.Namespace
-}
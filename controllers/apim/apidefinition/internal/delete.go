@@ -16,6 +16,8 @@ package internal
 
 import (
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -34,11 +36,49 @@ func (d *Delegate) Delete(
 		}
 	}
 
+	if err := d.deleteWithContexts(apiDefinition, apiDefinition.Status.Contexts); err != nil {
+		return err
+	}
+
 	util.RemoveFinalizer(apiDefinition, keys.ApiDefinitionDeletionFinalizer)
 
 	return d.k8s.Update(d.ctx, apiDefinition)
 }
 
+// deleteWithContexts deletes the API from the given contexts it has been synced with.
+// Contexts that can not be resolved anymore are skipped, as is the case with a single context.
+func (d *Delegate) deleteWithContexts(api *gio.ApiDefinition, statuses []gio.ContextStatus) error {
+	for _, status := range statuses {
+		if status.ID == "" {
+			continue
+		}
+
+		instance, err := apim.FromContextRef(d.ctx, d.k8s, status.ContextRef)
+		if err != nil {
+			d.log.Info("Unable to resolve context, API will not be deleted from it", "context", status.ContextRef.String())
+			continue
+		}
+
+		cp := api.DeepCopy()
+		cp.Status.ID = status.ID
+		delegate := &Delegate{ctx: d.ctx, k8s: d.k8s, log: d.log, apim: instance}
+		if err = delegate.deleteWithContext(cp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (d *Delegate) deleteWithContext(api *gio.ApiDefinition) error {
-	return errors.IgnoreNotFound(d.apim.APIs.Delete(api.Status.ID))
+	switch api.Spec.DeletionPolicy.Or(gio.DeletionPolicy(env.Config.DeletionPolicy)) {
+	case gio.DeletionPolicyRetain:
+		d.log.Info("Retaining API in APIM according to deletion policy", "id", api.Status.ID)
+		return nil
+	case gio.DeletionPolicyOrphan:
+		d.log.Info("Orphaning API in APIM according to deletion policy", "id", api.Status.ID)
+		return errors.IgnoreNotFound(d.apim.APIs.SetManagementContext(api.Status.ID))
+	default:
+		return errors.IgnoreNotFound(d.apim.APIs.Delete(api.Status.ID))
+	}
 }
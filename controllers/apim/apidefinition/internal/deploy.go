@@ -16,28 +16,83 @@ package internal
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 )
 
-func (d *Delegate) deploy(api *gio.ApiDefinition) error {
+// APIM rejects deployment labels longer than this.
+const maxLabelLength = 32
+
+// deploy deploys the API either locally or through APIM, in which case the deployment is recorded in history.
+func (d *Delegate) deploy(api *gio.ApiDefinition, history *[]gio.DeploymentStatus) error {
 	if api.Spec.IsLocal {
-		return d.updateConfigMap(api)
+		return d.updateLocalDefinition(api)
 	}
 
-	// Is a not-local and need to deploy it directly on APIM Console, no ConfigMap needed
+	// Is a not-local and need to deploy it directly on APIM Console, no local definition needed
 	if !d.HasContext() {
 		return errors.New("a non-local API definition must have a reference to a ManagementContext")
 	}
 
-	if err := d.deleteConfigMap(api); err != nil {
+	if err := d.deleteLocalDefinition(api); err != nil {
+		return err
+	}
+
+	label := deploymentLabel(api)
+	if err := d.apim.APIs.Deploy(api.Spec.ID, label); err != nil {
 		return err
 	}
 
-	return d.apim.APIs.Deploy(api.Spec.ID)
+	deployment := gio.DeploymentStatus{Generation: api.Generation, Label: label, Time: metav1.Now()}
+
+	// The deployment succeeded, failing to find its number is not worth failing the reconcile
+	if event, err := d.apim.APIs.LastDeployment(api.Spec.ID); err != nil {
+		d.log.Error(err, "Unable to find the last deployment of the API", "id", api.Spec.ID)
+	} else {
+		deployment.Number = event.DeploymentNumber()
+	}
+
+	*history = recordDeployment(*history, deployment, env.Config.DeploymentHistoryLimit)
+	return nil
+}
+
+// The label of a deployment is taken from the first configured annotation set on the API definition,
+// e.g. the Git revision the resource has been applied from, and defaults to the generation otherwise.
+func deploymentLabel(api *gio.ApiDefinition) string {
+	label := fmt.Sprintf("generation %d", api.Generation)
+	for _, key := range env.Config.DeploymentLabelKeys {
+		if value := api.GetAnnotations()[key]; value != "" {
+			label = value
+			break
+		}
+	}
+
+	// Truncating on characters keeps the label valid UTF-8
+	if runes := []rune(label); len(runes) > maxLabelLength {
+		return string(runes[:maxLabelLength])
+	}
+	return label
+}
+
+// recordDeployment adds the deployment at the head of history, keeping at most limit deployments.
+// A generation deployed again, e.g. to repair drift, replaces its previous deployment.
+func recordDeployment(
+	history []gio.DeploymentStatus, deployment gio.DeploymentStatus, limit int,
+) []gio.DeploymentStatus {
+	if len(history) > 0 && history[0].Generation == deployment.Generation {
+		history = history[1:]
+	}
+	history = append([]gio.DeploymentStatus{deployment}, history...)
+	if len(history) > limit {
+		return history[:limit]
+	}
+	return history
 }
 
 func (d *Delegate) updateState(api *gio.ApiDefinition) error {
@@ -0,0 +1,75 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+)
+
+var _ = Describe("Deployments", func() {
+	DescribeTable("Deployment label",
+		func(annotations map[string]string, expected string) {
+			api := newDriftApi()
+			api.Generation = 3
+			api.Annotations = annotations
+			Expect(deploymentLabel(api)).To(Equal(expected))
+		},
+		Entry("Without annotation", nil, "generation 3"),
+		Entry("With the deployment label annotation",
+			map[string]string{keys.DeploymentLabelAnnotation: "release 1.2"}, "release 1.2"),
+		Entry("With an empty annotation", map[string]string{keys.DeploymentLabelAnnotation: ""}, "generation 3"),
+		Entry("With a long label", map[string]string{keys.DeploymentLabelAnnotation: strings.Repeat("a", 40)},
+			strings.Repeat("a", 32)),
+		Entry("With a long multi-byte label",
+			map[string]string{keys.DeploymentLabelAnnotation: strings.Repeat("é", 40)}, strings.Repeat("é", 32)),
+	)
+
+	It("Should label deployments from the first configured annotation", func() {
+		labelKeys := env.Config.DeploymentLabelKeys
+		defer func() { env.Config.DeploymentLabelKeys = labelKeys }()
+		env.Config.DeploymentLabelKeys = []string{"example.com/revision", "example.com/commit"}
+
+		api := newDriftApi()
+		api.Annotations = map[string]string{"example.com/commit": "4f2c1e9"}
+		Expect(deploymentLabel(api)).To(Equal("4f2c1e9"))
+	})
+
+	It("Should keep the most recent deployments first", func() {
+		history := make([]gio.DeploymentStatus, 0)
+		for generation := int64(1); generation <= 4; generation++ {
+			history = recordDeployment(history, gio.DeploymentStatus{Generation: generation}, 3)
+		}
+
+		Expect(history).To(HaveLen(3))
+		Expect(history[0].Generation).To(Equal(int64(4)))
+		Expect(history[2].Generation).To(Equal(int64(2)))
+	})
+
+	It("Should replace the deployment of a generation deployed again", func() {
+		history := []gio.DeploymentStatus{{Generation: 2, Number: 2}, {Generation: 1, Number: 1}}
+		history = recordDeployment(history, gio.DeploymentStatus{Generation: 2, Number: 3}, 3)
+
+		Expect(history).To(HaveLen(2))
+		Expect(history[0].Number).To(Equal(3))
+		Expect(history[1].Generation).To(Equal(int64(1)))
+	})
+})
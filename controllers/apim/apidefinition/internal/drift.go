@@ -0,0 +1,204 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"time"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/diff"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+// The subset of an API that is compared with the API found in APIM to detect drift.
+type apiState struct {
+	Name           string               `json:"name"`
+	Version        string               `json:"version"`
+	Description    string               `json:"description"`
+	Visibility     string               `json:"visibility"`
+	LifecycleState string               `json:"lifecycle_state"`
+	State          string               `json:"state,omitempty"`
+	Tags           []string             `json:"tags"`
+	Labels         []string             `json:"labels"`
+	Plans          map[string]planState `json:"plans"`
+}
+
+type planState struct {
+	Security string `json:"security"`
+	Status   string `json:"status"`
+}
+
+// ResyncPeriod returns the period at which the API should be compared with its state in APIM.
+// An API synced with multiple contexts is compared at the shortest resync period of its contexts.
+func (d *Delegate) ResyncPeriod(api *gio.ApiDefinition) time.Duration {
+	if d.HasContext() {
+		return d.apim.ResyncPeriod()
+	}
+
+	period := time.Duration(0)
+	for _, target := range api.Spec.Contexts {
+		instance, err := apim.FromContextRef(d.ctx, d.k8s, target.ContextRef)
+		if err != nil {
+			continue
+		}
+		if resync := instance.ResyncPeriod(); resync > 0 && (period == 0 || resync < period) {
+			period = resync
+		}
+	}
+	return period
+}
+
+// DetectDrift returns the differences between the API definition and the API found in APIM.
+// Drift is only detected if the API has been synced with its current generation,
+// otherwise differences are expected and will be applied anyway.
+func (d *Delegate) DetectDrift(api *gio.ApiDefinition) ([]string, error) {
+	if api.Status.ObservedGeneration != api.Generation || api.Status.Status != gio.ProcessingStatusCompleted {
+		return nil, nil
+	}
+
+	if len(api.Spec.Contexts) > 0 {
+		return d.detectContextsDrift(api)
+	}
+
+	if !d.HasContext() || d.apim.ResyncPeriod() == 0 || api.Status.ID == "" {
+		return nil, nil
+	}
+
+	return d.detectDrift(api)
+}
+
+// detectContextsDrift detects drift in each of the contexts of the API, once their overrides applied.
+// Differences are prefixed with the context they have been found in.
+func (d *Delegate) detectContextsDrift(api *gio.ApiDefinition) ([]string, error) {
+	drift := make([]string, 0)
+	for _, target := range api.Spec.Contexts {
+		status := api.ContextStatus(target.ContextRef)
+		if status == nil || status.ID == "" {
+			continue
+		}
+
+		instance, err := apim.FromContextRef(d.ctx, d.k8s, target.ContextRef)
+		if err != nil {
+			return nil, err
+		}
+
+		if instance.ResyncPeriod() == 0 {
+			continue
+		}
+
+		cp := api.DeepCopy()
+		cp.Status.ID = status.ID
+		applyOverrides(&cp.Spec, target.Overrides)
+
+		delegate := &Delegate{ctx: d.ctx, k8s: d.k8s, log: d.log, apim: instance}
+		changes, err := delegate.detectDrift(cp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, change := range changes {
+			drift = append(drift, target.ContextRef.String()+": "+change)
+		}
+	}
+	return drift, nil
+}
+
+func (d *Delegate) detectDrift(api *gio.ApiDefinition) ([]string, error) {
+	mgmtApi, err := d.apim.APIs.GetByID(api.Status.ID)
+	if errors.IsNotFound(err) {
+		return []string{"API not found in the API Management instance"}, nil
+	}
+	if err != nil {
+		return nil, apim.NewContextError(err)
+	}
+
+	return diff.Compare(desiredApiState(api), actualApiState(mgmtApi))
+}
+
+// AppliedHash returns the hash of what is applied for the API definition, including the resources it
+// references and the management context it is synced with, as none of them changes its generation.
+func (d *Delegate) AppliedHash(api *gio.ApiDefinition) (string, error) {
+	cp := api.DeepCopy()
+	if err := d.resolveResources(&cp.Spec); err != nil {
+		return "", err
+	}
+
+	applied := struct {
+		Spec  gio.ApiDefinitionSpec `json:"spec"`
+		OrgID string                `json:"organizationId,omitempty"`
+		EnvID string                `json:"environmentId,omitempty"`
+	}{Spec: cp.Spec}
+
+	if d.HasContext() {
+		applied.OrgID, applied.EnvID = d.apim.OrgID(), d.apim.EnvID()
+	}
+
+	return diff.Hash(applied)
+}
+
+// IsApplied returns true if the current generation of the API definition has been applied with the given hash.
+func IsApplied(api *gio.ApiDefinition, hash string) bool {
+	return api.Status.ObservedGeneration == api.Generation &&
+		api.Status.Status == gio.ProcessingStatusCompleted && api.Status.AppliedHash == hash
+}
+
+func desiredApiState(api *gio.ApiDefinition) *apiState {
+	spec := &api.Spec
+	state := &apiState{
+		Name:           spec.Name,
+		Version:        spec.Version,
+		Description:    spec.Description,
+		Visibility:     string(spec.Visibility),
+		LifecycleState: string(spec.LifecycleState),
+		Tags:           spec.Tags,
+		Labels:         spec.Labels,
+		Plans:          make(map[string]planState),
+	}
+
+	// The state of local APIs is handled by the gateway, not by APIM.
+	if !spec.IsLocal {
+		state.State = spec.State
+	}
+
+	for _, plan := range spec.Plans {
+		if plan.Plan != nil {
+			state.Plans[plan.Name] = planState{Security: plan.Security, Status: string(plan.Status)}
+		}
+	}
+
+	return state
+}
+
+func actualApiState(api *model.ApiEntity) *apiState {
+	state := &apiState{
+		Name:           api.Name,
+		Version:        api.Version,
+		Description:    api.Description,
+		Visibility:     api.Visibility,
+		LifecycleState: api.ApiLifecycleState,
+		State:          api.State,
+		Tags:           api.Tags,
+		Labels:         api.Labels,
+		Plans:          make(map[string]planState),
+	}
+
+	for _, plan := range api.Plans {
+		state.Plans[plan.Name] = planState{Security: string(plan.Security), Status: string(plan.Status)}
+	}
+
+	return state
+}
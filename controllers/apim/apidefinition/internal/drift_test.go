@@ -0,0 +1,113 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	v2 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v2"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/diff"
+)
+
+func newDriftApi() *gio.ApiDefinition {
+	return &gio.ApiDefinition{
+		Spec: gio.ApiDefinitionSpec{
+			Api: v2.Api{
+				ApiBase: &base.ApiBase{
+					Name:           "api",
+					State:          base.StateStarted,
+					Visibility:     "PRIVATE",
+					LifecycleState: "CREATED",
+				},
+				Version: "1.0.0",
+				Plans: []*v2.Plan{
+					{Plan: &base.Plan{Name: "free", Status: "PUBLISHED"}, Security: "KEY_LESS"},
+				},
+			},
+		},
+	}
+}
+
+func newDriftApiEntity() *model.ApiEntity {
+	return &model.ApiEntity{
+		ID:                "api-id",
+		Name:              "api",
+		Version:           "1.0.0",
+		State:             base.StateStarted,
+		Visibility:        "PRIVATE",
+		ApiLifecycleState: "CREATED",
+		Plans: []*model.Plan{
+			{Id: "plan-id", Name: "free", Security: "KEY_LESS", Status: "PUBLISHED"},
+			{Id: "closed-plan-id", Name: "closed", Security: "API_KEY", Status: "CLOSED"},
+		},
+	}
+}
+
+var _ = Describe("Drift", func() {
+	DescribeTable("API state compared with APIM",
+		func(mutate func(*model.ApiEntity), local bool, expected []string) {
+			api := newDriftApi()
+			api.Spec.IsLocal = local
+			entity := newDriftApiEntity()
+			mutate(entity)
+
+			drift, err := diff.Compare(desiredApiState(api), actualApiState(entity))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drift).To(Equal(expected))
+		},
+		Entry("With no change", func(*model.ApiEntity) {}, false, []string{}),
+		Entry("With a renamed API", func(e *model.ApiEntity) {
+			e.Name = "renamed"
+		}, false, []string{`name: expected "api", found "renamed"`}),
+		Entry("With a stopped API", func(e *model.ApiEntity) {
+			e.State = base.StateStopped
+		}, false, []string{`state: expected "STARTED", found "STOPPED"`}),
+		Entry("With a stopped local API", func(e *model.ApiEntity) {
+			e.State = base.StateStopped
+		}, true, []string{}),
+		Entry("With a closed plan", func(e *model.ApiEntity) {
+			e.Plans[0].Status = "CLOSED"
+		}, false, []string{`plans.free.status: expected "PUBLISHED", found "CLOSED"`}),
+		Entry("With a deleted plan", func(e *model.ApiEntity) {
+			e.Plans = e.Plans[1:]
+		}, false, []string{`plans.free: expected {"security":"KEY_LESS","status":"PUBLISHED"}, found none`}),
+	)
+	It("Should only consider the API applied while its generation and hash are unchanged", func() {
+		d := &Delegate{}
+		api := newDriftApi()
+		api.Generation = 2
+
+		hash, err := d.AppliedHash(api)
+		Expect(err).ToNot(HaveOccurred())
+
+		api.Status.ObservedGeneration = 2
+		api.Status.Status = gio.ProcessingStatusCompleted
+		api.Status.AppliedHash = hash
+		Expect(IsApplied(api, hash)).To(BeTrue())
+
+		// e.g. a value resolved from a config map has changed
+		api.Spec.Description = "resolved"
+		changed, err := d.AppliedHash(api)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(IsApplied(api, changed)).To(BeFalse())
+
+		api.Generation = 3
+		Expect(IsApplied(api, hash)).To(BeFalse())
+	})
+})
@@ -0,0 +1,124 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/diff"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+const apiCreated = "API would be created"
+
+// DryRun reports in the status of the API definition the differences between the API that would be imported
+// into each of its management contexts, after resolving its resources and plans, and the API found in APIM.
+// Nothing is imported, deployed or started. The values of the fields that are templated in the unresolved
+// API definition are not reported, as they may have been read from secrets.
+func (d *Delegate) DryRun(apiDefinition, unresolved *gio.ApiDefinition) error {
+	if !d.HasContext() && len(apiDefinition.Spec.Contexts) == 0 {
+		return apim.NewUnrecoverableError(fmt.Errorf("a dry run requires a management context"))
+	}
+
+	cp := apiDefinition.DeepCopy()
+	spec := &cp.Spec
+	spec.ID = cp.PickID()
+	spec.CrossID = cp.PickCrossID()
+
+	if err := d.resolveResources(spec); err != nil {
+		d.log.Error(err, "unable to resolve resources")
+		return err
+	}
+
+	templated := unresolved.DeepCopy()
+	if err := d.lookupResources(&templated.Spec); err != nil {
+		return err
+	}
+
+	generateEmptyPlanCrossIds(spec)
+
+	if len(spec.Contexts) == 0 {
+		result, err := d.dryRun(cp, templated, *spec.Context)
+		if err != nil {
+			return err
+		}
+		apiDefinition.Status.DryRun = []gio.DryRunResult{*result}
+		return nil
+	}
+
+	spec.ID = baseID(cp)
+	results := make([]gio.DryRunResult, 0, len(spec.Contexts))
+	for i, target := range spec.Contexts {
+		instance, err := apim.FromContextRef(d.ctx, d.k8s, target.ContextRef)
+		if err != nil {
+			return err
+		}
+
+		status := gio.ContextStatus{ContextRef: target.ContextRef}
+		if previous := apiDefinition.ContextStatus(target.ContextRef); previous != nil {
+			status = *previous
+		}
+
+		contextApi := cp.DeepCopy()
+		contextApi.Spec.ID = contextID(cp, target.ContextRef, &status)
+		contextApi.Status.ID = status.ID
+		applyOverrides(&contextApi.Spec, target.Overrides)
+
+		contextTemplated := templated.DeepCopy()
+		if i < len(contextTemplated.Spec.Contexts) {
+			applyOverrides(&contextTemplated.Spec, contextTemplated.Spec.Contexts[i].Overrides)
+		}
+
+		delegate := &Delegate{ctx: d.ctx, k8s: d.k8s, log: d.log, apim: instance}
+		result, err := delegate.dryRun(contextApi, contextTemplated, target.ContextRef)
+		if err != nil {
+			return err
+		}
+		results = append(results, *result)
+	}
+
+	apiDefinition.Status.DryRun = results
+	return nil
+}
+
+func (d *Delegate) dryRun(api, templated *gio.ApiDefinition, ref refs.NamespacedName) (*gio.DryRunResult, error) {
+	spec := &api.Spec
+	spec.SetDefinitionContext()
+
+	result := &gio.DryRunResult{ContextRef: ref}
+
+	id, err := d.findApiID(api)
+	if errors.IsNotFound(err) {
+		result.Changes = []string{apiCreated}
+		return result, nil
+	}
+	if err != nil {
+		return nil, apim.NewContextError(err)
+	}
+
+	exported, err := d.apim.APIs.Export(id)
+	if err != nil {
+		return nil, apim.NewContextError(err)
+	}
+
+	if result.Changes, err = diff.CompareRedacted(&spec.Api, exported, &templated.Spec.Api); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	netHttp "net/http"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Dry run", func() {
+	var server *httptest.Server
+	var existing bool
+
+	BeforeEach(func() {
+		existing = false
+		server = httptest.NewServer(netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(netHttp.MethodGet))
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/management/organizations/DEFAULT/environments/DEFAULT/apis":
+				if existing {
+					_, _ = w.Write([]byte(`[{"id":"api-id"}]`))
+				} else {
+					_, _ = w.Write([]byte(`[]`))
+				}
+			case "/management/organizations/DEFAULT/environments/DEFAULT/apis/api-id/export":
+				_, _ = w.Write([]byte(`{"id":"api-id","name":"renamed","version":"1.0.0","description":"previous-s3cr3t"}`))
+			default:
+				Fail("unexpected request to " + r.URL.Path)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newDelegate := func(objects ...client.Object) *Delegate {
+		instance, err := apim.FromContext(context.Background(), management.Context{
+			BaseUrl: server.URL,
+			OrgId:   "DEFAULT",
+			EnvId:   "DEFAULT",
+			Auth:    &management.Auth{BearerToken: "token"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		k8s := fake.NewClientBuilder().WithObjects(objects...).Build()
+		return &Delegate{ctx: context.Background(), k8s: k8s, log: logr.Discard(), apim: instance}
+	}
+
+	newDryRunApi := func() *gio.ApiDefinition {
+		api := newDriftApi()
+		api.Name = "api"
+		api.Namespace = "default"
+		api.Spec.Context = &refs.NamespacedName{Name: "dev-ctx", Namespace: "default"}
+		return api
+	}
+
+	It("Should report an API that does not exist yet", func() {
+		api := newDryRunApi()
+		Expect(newDelegate().DryRun(api, api.DeepCopy())).To(Succeed())
+		Expect(api.Status.DryRun).To(HaveLen(1))
+		Expect(api.Status.DryRun[0].ContextRef.Name).To(Equal("dev-ctx"))
+		Expect(api.Status.DryRun[0].Changes).To(Equal([]string{apiCreated}))
+	})
+
+	It("Should report the differences with the API found in APIM", func() {
+		existing = true
+		api := newDryRunApi()
+		Expect(newDelegate().DryRun(api, api.DeepCopy())).To(Succeed())
+		Expect(api.Status.DryRun).To(HaveLen(1))
+		Expect(api.Status.DryRun[0].Changes).To(ContainElement(`name: expected "api", found "renamed"`))
+		Expect(api.Spec.CrossID).To(BeEmpty())
+	})
+
+	It("Should not report the values resolved from templates", func() {
+		existing = true
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api-secrets"},
+			Data:       map[string][]byte{"description": []byte("s3cr3t")},
+		}
+		d := newDelegate(secret)
+
+		unresolved := newDryRunApi()
+		unresolved.Spec.Description = `[[ secret "api-secrets/description" ]]`
+		api := unresolved.DeepCopy()
+		Expect(d.ResolveTemplate(api)).To(Succeed())
+		Expect(api.Spec.Description).To(Equal("s3cr3t"))
+
+		Expect(d.DryRun(api, unresolved)).To(Succeed())
+		Expect(api.Status.DryRun).To(HaveLen(1))
+		Expect(api.Status.DryRun[0].Changes).To(ContainElements(
+			`description: expected "<redacted>", found "<redacted>"`,
+			`name: expected "api", found "renamed"`,
+		))
+
+		status, err := json.Marshal(api.Status)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(status)).ToNot(ContainSubstring("s3cr3t"))
+
+		recorder := record.NewFakeRecorder(len(api.Status.DryRun))
+		events := event.NewRecorder(recorder)
+		for _, result := range api.Status.DryRun {
+			events.RecordDryRun(api, result.ContextRef.String(), result.Changes)
+		}
+		var message string
+		Expect(recorder.Events).To(Receive(&message))
+		Expect(message).To(ContainSubstring("<redacted>"))
+		Expect(message).ToNot(ContainSubstring("s3cr3t"))
+	})
+})
@@ -0,0 +1,331 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	definitionVersionKey = "definitionVersion"
+	definitionKey        = "definition"
+	managedByKey         = "managed-by"
+	gioTypeKey           = "gio-type"
+	orgKey               = "organizationId"
+	envKey               = "environmentId"
+)
+
+func (d *Delegate) updateLocalDefinition(api *gio.ApiDefinition) error {
+	_, span := tracing.Start(
+		d.ctx, "localdefinition.Update",
+		tracing.String("k8s.kind", env.Config.LocalDefinitionStore),
+		tracing.String("k8s.name", api.Name),
+	)
+	err := d.writeLocalDefinition(api)
+	span.End(err)
+	return err
+}
+
+func (d *Delegate) writeLocalDefinition(api *gio.ApiDefinition) error {
+	if api.Spec.State == base.StateStopped {
+		if err := d.deleteLocalDefinition(api); err != nil {
+			d.log.Error(err, "Unable to delete local definition of API")
+			return err
+		}
+	} else {
+		if err := d.saveLocalDefinition(api); err != nil {
+			d.log.Error(err, "Unable to create or update local definition of API", "kind", env.Config.LocalDefinitionStore)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveLocalDefinition writes the definition of the API to a config map or to a secret, depending on
+// the configured store. The definition is resolved and may contain values read from secrets.
+func (d *Delegate) saveLocalDefinition(
+	apiDefinition *gio.ApiDefinition,
+) error {
+	if apiDefinition.Spec.State == base.StateStopped {
+		return nil
+	}
+
+	// Set OwnerReference on the definition to be able to delete it when API is deleted.
+	// 📝 The definition should be in same namespace as ApiDefinition.
+	objectMeta := metav1.ObjectMeta{
+		Namespace: apiDefinition.Namespace,
+		Name:      apiDefinition.Name,
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				Kind:       apiDefinition.Kind,
+				Name:       apiDefinition.Name,
+				APIVersion: apiDefinition.APIVersion,
+				UID:        apiDefinition.UID,
+			},
+		},
+		CreationTimestamp: metav1.Now(),
+		Labels: map[string]string{
+			managedByKey: keys.CrdGroup,
+			gioTypeKey:   keys.CrdApiDefinitionResource + "." + keys.CrdGroup,
+		},
+	}
+
+	// Some specific metadata will be used to check changes across 'Update' events.
+	data := map[string]string{
+		definitionVersionKey: apiDefinition.ResourceVersion,
+	}
+
+	spec := &(apiDefinition.Spec)
+
+	if d.apim != nil {
+		data[orgKey] = d.apim.OrgID()
+		data[envKey] = d.apim.EnvID()
+	}
+
+	if spec.ID == "" {
+		spec.ID = string(apiDefinition.UID)
+	}
+
+	jsonSpec, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	format, contents, err := encodeDefinition(apiDefinition.Name, jsonSpec, data)
+	if err != nil {
+		return err
+	}
+
+	objectMeta.Labels[formatKey] = format
+	definition := newLocalDefinition(objectMeta, data)
+	parts := newDefinitionParts(objectMeta, data[definitionChecksumKey], contents)
+	if err = d.storeLocalDefinition(apiDefinition, definition, parts); err != nil {
+		return err
+	}
+
+	// The definition may have been written to a config map before the store was changed,
+	// which is kept for the gateways reading config maps until migrating is enabled
+	if env.Config.LocalDefinitionStore == env.SecretStore && env.Config.MigrateLocalDefinitions {
+		return d.deleteDefinitionConfigMap(apiDefinition)
+	}
+
+	return nil
+}
+
+// storeLocalDefinition writes the parts of the definition, if any, before the definition listing them,
+// parts of the previous definition being deleted once it is replaced, so that a gateway never reads
+// a definition whose parts are missing or belong to another definition.
+func (d *Delegate) storeLocalDefinition(
+	apiDefinition *gio.ApiDefinition, definition client.Object, parts []client.Object,
+) error {
+	current := newLocalDefinition(metav1.ObjectMeta{}, nil)
+
+	err := d.k8s.Get(d.ctx, client.ObjectKeyFromObject(definition), current)
+	if errors.IsNotFound(err) {
+		d.log.Info(
+			"Creating local definition for API.",
+			"id", apiDefinition.Spec.ID, "name", apiDefinition.Name, "kind", env.Config.LocalDefinitionStore,
+		)
+		if err = d.storeDefinitionParts(parts); err != nil {
+			return err
+		}
+		if err = d.k8s.Create(d.ctx, definition); err != nil {
+			return err
+		}
+		// Parts of a previous definition may have been left over, e.g. if deleting them failed
+		return d.deleteDefinitionParts(apiDefinition, newLocalDefinitionList(), parts...)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !isManaged(current) {
+		return fmt.Errorf(
+			"%s %s already exists and is not managed by the operator", env.Config.LocalDefinitionStore, current.GetName(),
+		)
+	}
+
+	// Only update the definition if resource version has changed (means api definition has changed).
+	if definitionVersion(current) != apiDefinition.ResourceVersion {
+		d.log.Info("Updating local definition", "id", apiDefinition.Spec.ID, "kind", env.Config.LocalDefinitionStore)
+		if err = d.storeDefinitionParts(parts); err != nil {
+			return err
+		}
+		if err = d.k8s.Update(d.ctx, definition); err != nil {
+			return err
+		}
+		return d.deleteDefinitionParts(apiDefinition, newLocalDefinitionList(), parts...)
+	}
+
+	d.log.Info("No change detected on API. Skipped.", "id", apiDefinition.Spec.ID)
+	return nil
+}
+
+// newLocalDefinition returns an object of the configured store kind, holding the given data.
+func newLocalDefinition(objectMeta metav1.ObjectMeta, data map[string]string) client.Object {
+	if env.Config.LocalDefinitionStore != env.SecretStore {
+		return &v1.ConfigMap{ObjectMeta: objectMeta, Data: data}
+	}
+
+	secret := &v1.Secret{ObjectMeta: objectMeta, Type: v1.SecretTypeOpaque, Data: make(map[string][]byte)}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+
+	return secret
+}
+
+// newDefinitionParts returns the objects holding the parts of a sharded definition. Parts are not labelled
+// with the type of the definition, gateways only reading them through the definition listing them.
+func newDefinitionParts(objectMeta metav1.ObjectMeta, checksum string, contents []string) []client.Object {
+	parts := make([]client.Object, 0, len(contents))
+	for i, content := range contents {
+		partMeta := metav1.ObjectMeta{
+			Namespace:         objectMeta.Namespace,
+			Name:              partName(objectMeta.Name, checksum, i),
+			OwnerReferences:   objectMeta.OwnerReferences,
+			CreationTimestamp: objectMeta.CreationTimestamp,
+			Labels: map[string]string{
+				managedByKey: keys.CrdGroup,
+				partOfKey:    objectMeta.Name,
+				formatKey:    formatSharded,
+			},
+		}
+		parts = append(parts, newLocalDefinition(partMeta, map[string]string{definitionKey: content}))
+	}
+	return parts
+}
+
+func newLocalDefinitionList() client.ObjectList {
+	if env.Config.LocalDefinitionStore != env.SecretStore {
+		return &v1.ConfigMapList{}
+	}
+	return &v1.SecretList{}
+}
+
+func (d *Delegate) storeDefinitionParts(parts []client.Object) error {
+	for _, part := range parts {
+		current := newLocalDefinition(metav1.ObjectMeta{}, nil)
+		err := d.k8s.Get(d.ctx, client.ObjectKeyFromObject(part), current)
+		switch {
+		case errors.IsNotFound(err):
+			err = d.k8s.Create(d.ctx, part)
+		case err != nil:
+		case !isManaged(current):
+			err = fmt.Errorf(
+				"%s %s already exists and is not managed by the operator", env.Config.LocalDefinitionStore, current.GetName(),
+			)
+		default:
+			err = d.k8s.Update(d.ctx, part)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteDefinitionParts deletes the parts of the definition of the API found in the given list kind,
+// except for the parts to keep.
+func (d *Delegate) deleteDefinitionParts(api *gio.ApiDefinition, list client.ObjectList, keep ...client.Object) error {
+	err := d.k8s.List(
+		d.ctx, list, client.InNamespace(api.Namespace),
+		client.MatchingLabels{managedByKey: keys.CrdGroup, partOfKey: api.Name},
+	)
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, part := range keep {
+		kept[part.GetName()] = true
+	}
+
+	return meta.EachListItem(list, func(obj runtime.Object) error {
+		part, ok := obj.(client.Object)
+		if !ok || kept[part.GetName()] {
+			return nil
+		}
+		return client.IgnoreNotFound(d.k8s.Delete(d.ctx, part))
+	})
+}
+
+func isManaged(definition client.Object) bool {
+	return definition.GetLabels()[managedByKey] == keys.CrdGroup
+}
+
+func definitionVersion(definition client.Object) string {
+	switch t := definition.(type) {
+	case *v1.Secret:
+		return string(t.Data[definitionVersionKey])
+	case *v1.ConfigMap:
+		return t.Data[definitionVersionKey]
+	default:
+		return ""
+	}
+}
+
+// deleteLocalDefinition deletes the definition of the API from both stores,
+// as it may have been written to a config map before the store was changed.
+func (d *Delegate) deleteLocalDefinition(api *gio.ApiDefinition) error {
+	if err := d.deleteDefinitionConfigMap(api); err != nil {
+		return err
+	}
+
+	if err := d.deleteDefinitionParts(api, &v1.SecretList{}); err != nil {
+		return err
+	}
+
+	return d.deleteManagedDefinition(api, &v1.Secret{})
+}
+
+func (d *Delegate) deleteDefinitionConfigMap(api *gio.ApiDefinition) error {
+	if err := d.deleteManagedDefinition(api, &v1.ConfigMap{}); err != nil {
+		return err
+	}
+
+	return d.deleteDefinitionParts(api, &v1.ConfigMapList{})
+}
+
+// deleteManagedDefinition deletes the object of the given kind named after the API, if it is managed
+// by the operator. An object named after the API is not necessarily holding its definition.
+func (d *Delegate) deleteManagedDefinition(api *gio.ApiDefinition, definition client.Object) error {
+	if err := d.k8s.Get(d.ctx, client.ObjectKeyFromObject(api), definition); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !isManaged(definition) {
+		return nil
+	}
+
+	d.log.Info("Deleting local definition of API", "name", definition.GetName())
+	return client.IgnoreNotFound(d.k8s.Delete(d.ctx, definition))
+}
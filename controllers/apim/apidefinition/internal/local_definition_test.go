@@ -0,0 +1,248 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Local definition", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "api"}
+	managed := map[string]string{managedByKey: keys.CrdGroup}
+
+	var store string
+	var migrate bool
+	var maxSize int
+
+	BeforeEach(func() {
+		store, migrate, maxSize = env.Config.LocalDefinitionStore, env.Config.MigrateLocalDefinitions, maxDataSize
+		env.Config.LocalDefinitionStore = env.SecretStore
+	})
+
+	AfterEach(func() {
+		env.Config.LocalDefinitionStore, env.Config.MigrateLocalDefinitions, maxDataSize = store, migrate, maxSize
+	})
+
+	newDelegate := func(objects ...client.Object) *Delegate {
+		k8s := fake.NewClientBuilder().WithObjects(objects...).Build()
+		return &Delegate{ctx: context.Background(), k8s: k8s, log: logr.Discard()}
+	}
+
+	newConfigMap := func(labels map[string]string) *v1.ConfigMap {
+		return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api", Labels: labels}}
+	}
+
+	newLocalApi := func() *gio.ApiDefinition {
+		api := newDriftApi()
+		api.Name = "api"
+		api.Namespace = "default"
+		api.ResourceVersion = "42"
+		api.Spec.IsLocal = true
+		return api
+	}
+
+	newLargeApi := func() *gio.ApiDefinition {
+		api := newLocalApi()
+		api.Spec.Description = strings.Repeat("large ", 2000)
+		return api
+	}
+
+	decompress := func(value string) string {
+		compressed, err := base64.StdEncoding.DecodeString(value)
+		Expect(err).ToNot(HaveOccurred())
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		Expect(err).ToNot(HaveOccurred())
+		definition, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		return string(definition)
+	}
+
+	It("Should write the definition to a secret", func() {
+		d := newDelegate()
+		Expect(d.updateLocalDefinition(newLocalApi())).To(Succeed())
+
+		secret := &v1.Secret{}
+		Expect(d.k8s.Get(d.ctx, key, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue(managedByKey, keys.CrdGroup))
+		Expect(string(secret.Data[definitionVersionKey])).To(Equal("42"))
+		Expect(string(secret.Data[definitionKey])).To(ContainSubstring(`"name":"api"`))
+		Expect(errors.IsNotFound(d.k8s.Get(d.ctx, key, &v1.ConfigMap{}))).To(BeTrue())
+	})
+
+	It("Should keep the previous config map unless migrating", func() {
+		d := newDelegate(newConfigMap(managed))
+		Expect(d.updateLocalDefinition(newLocalApi())).To(Succeed())
+		Expect(d.k8s.Get(d.ctx, key, &v1.ConfigMap{})).To(Succeed())
+
+		env.Config.MigrateLocalDefinitions = true
+		Expect(d.updateLocalDefinition(newLocalApi())).To(Succeed())
+		Expect(errors.IsNotFound(d.k8s.Get(d.ctx, key, &v1.ConfigMap{}))).To(BeTrue())
+		Expect(d.k8s.Get(d.ctx, key, &v1.Secret{})).To(Succeed())
+	})
+
+	It("Should not delete a config map that is not managed by the operator", func() {
+		env.Config.MigrateLocalDefinitions = true
+		d := newDelegate(newConfigMap(nil))
+		Expect(d.updateLocalDefinition(newLocalApi())).To(Succeed())
+		Expect(d.k8s.Get(d.ctx, key, &v1.ConfigMap{})).To(Succeed())
+	})
+
+	It("Should not overwrite a secret that is not managed by the operator", func() {
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}
+		d := newDelegate(secret)
+		Expect(d.updateLocalDefinition(newLocalApi())).ToNot(Succeed())
+
+		Expect(d.deleteLocalDefinition(newLocalApi())).To(Succeed())
+		Expect(d.k8s.Get(d.ctx, key, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKey("password"))
+	})
+
+	It("Should delete the definition from both stores", func() {
+		d := newDelegate(newConfigMap(managed), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api", Labels: managed},
+		})
+		Expect(d.deleteLocalDefinition(newLocalApi())).To(Succeed())
+
+		Expect(errors.IsNotFound(d.k8s.Get(d.ctx, key, &v1.ConfigMap{}))).To(BeTrue())
+		Expect(errors.IsNotFound(d.k8s.Get(d.ctx, key, &v1.Secret{}))).To(BeTrue())
+	})
+
+	It("Should write definitions that fit in a single object as is", func() {
+		d := newDelegate()
+		Expect(d.updateLocalDefinition(newLargeApi())).To(Succeed())
+
+		secret := &v1.Secret{}
+		Expect(d.k8s.Get(d.ctx, key, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue(formatKey, formatJSON))
+		Expect(string(secret.Data[definitionKey])).To(ContainSubstring("large large"))
+	})
+
+	It("Should compress definitions that do not fit in a single object", func() {
+		maxDataSize = 4096
+		d := newDelegate()
+		Expect(d.updateLocalDefinition(newLargeApi())).To(Succeed())
+
+		secret := &v1.Secret{}
+		Expect(d.k8s.Get(d.ctx, key, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue(formatKey, formatGzip))
+		Expect(decompress(string(secret.Data[definitionKey]))).To(ContainSubstring(`"name":"api"`))
+	})
+
+	It("Should split definitions that do not fit in a single object once compressed", func() {
+		maxDataSize = 64
+		d := newDelegate()
+		Expect(d.updateLocalDefinition(newLargeApi())).To(Succeed())
+
+		secret := &v1.Secret{}
+		Expect(d.k8s.Get(d.ctx, key, secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue(formatKey, formatSharded))
+		Expect(secret.Data).ToNot(HaveKey(definitionKey))
+
+		names := strings.Split(string(secret.Data[definitionPartsKey]), ",")
+		Expect(len(names)).To(BeNumerically(">", 1))
+
+		compressed := ""
+		for _, name := range names {
+			part := &v1.Secret{}
+			Expect(d.k8s.Get(d.ctx, types.NamespacedName{Namespace: "default", Name: name}, part)).To(Succeed())
+			Expect(part.Labels).To(HaveKeyWithValue(partOfKey, "api"))
+			Expect(part.Labels).ToNot(HaveKey(gioTypeKey))
+			compressed += string(part.Data[definitionKey])
+		}
+		Expect(decompress(compressed)).To(ContainSubstring(`"name":"api"`))
+
+		maxDataSize = maxSize
+		api := newLargeApi()
+		api.ResourceVersion = "43"
+		Expect(d.updateLocalDefinition(api)).To(Succeed())
+
+		parts := &v1.SecretList{}
+		Expect(d.k8s.List(d.ctx, parts, client.MatchingLabels{partOfKey: "api"})).To(Succeed())
+		Expect(parts.Items).To(BeEmpty())
+	})
+
+	It("Should write the parts of a changed definition under new names", func() {
+		maxDataSize = 64
+		d := newDelegate()
+		Expect(d.updateLocalDefinition(newLargeApi())).To(Succeed())
+
+		secret := &v1.Secret{}
+		Expect(d.k8s.Get(d.ctx, key, secret)).To(Succeed())
+		previous := strings.Split(string(secret.Data[definitionPartsKey]), ",")
+
+		api := newLargeApi()
+		api.ResourceVersion = "43"
+		api.Spec.Description = strings.Repeat("changed ", 2000)
+		Expect(d.updateLocalDefinition(api)).To(Succeed())
+
+		Expect(d.k8s.Get(d.ctx, key, secret)).To(Succeed())
+		names := strings.Split(string(secret.Data[definitionPartsKey]), ",")
+		Expect(names).ToNot(ContainElements(previous))
+
+		parts := &v1.SecretList{}
+		Expect(d.k8s.List(d.ctx, parts, client.MatchingLabels{partOfKey: "api"})).To(Succeed())
+		Expect(parts.Items).To(HaveLen(len(names)))
+		for _, part := range parts.Items {
+			Expect(names).To(ContainElement(part.Name))
+		}
+	})
+
+	It("Should delete parts left over when creating a definition", func() {
+		maxDataSize = 64
+		stale := &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "api-part-stale-1",
+			Labels: map[string]string{managedByKey: keys.CrdGroup, partOfKey: "api"},
+		}}
+		d := newDelegate(stale)
+		Expect(d.updateLocalDefinition(newLargeApi())).To(Succeed())
+
+		Expect(errors.IsNotFound(d.k8s.Get(d.ctx, client.ObjectKeyFromObject(stale), &v1.Secret{}))).To(BeTrue())
+	})
+
+	It("Should report definitions that are too large to be stored", func() {
+		maxDataSize = 8
+		d := newDelegate()
+		err := d.updateLocalDefinition(newLargeApi())
+		Expect(err).To(HaveOccurred())
+
+		conditionType, reason := conditions.FromError(err)
+		Expect(conditionType).To(Equal(conditions.Deployed))
+		Expect(reason).To(Equal(conditions.ReasonTooLarge))
+		Expect(errors.IsNotFound(d.k8s.Get(d.ctx, key, &v1.Secret{}))).To(BeTrue())
+	})
+})
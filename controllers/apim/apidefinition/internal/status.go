@@ -15,7 +15,12 @@
 package internal
 
 import (
+	"fmt"
+	"strings"
+
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 )
 
 func (d *Delegate) UpdateStatusSuccess(api *gio.ApiDefinition) error {
@@ -23,10 +28,54 @@ func (d *Delegate) UpdateStatusSuccess(api *gio.ApiDefinition) error {
 		return nil
 	}
 	api.Status.ObservedGeneration = api.ObjectMeta.Generation
+	d.setSucceededConditions(api)
 	return d.k8s.Status().Update(d.ctx, api)
 }
 
-func (d *Delegate) UpdateStatusFailure(api *gio.ApiDefinition) error {
+func (d *Delegate) UpdateStatusFailure(api *gio.ApiDefinition, err error) error {
 	api.Status.Status = gio.ProcessingStatusFailed
+	conditions.SetFailed(&api.Status.Conditions, api.Generation, err)
+	return d.k8s.Status().Update(d.ctx, api)
+}
+
+// UpdateStatusDryRun records the result of a dry run without advancing the observed generation,
+// the API definition being accepted but not synced with APIM.
+func (d *Delegate) UpdateStatusDryRun(api *gio.ApiDefinition) error {
+	err := fmt.Errorf("dry run requested with the %s annotation, nothing has been applied", keys.DryRunAnnotation)
+	conditions.SetAccepted(&api.Status.Conditions, api.Generation)
+	conditions.SetFailed(
+		&api.Status.Conditions, api.Generation,
+		conditions.NewError(conditions.Synced, conditions.ReasonDryRun, err),
+	)
 	return d.k8s.Status().Update(d.ctx, api)
 }
+
+// An API definition referencing a context that can not be resolved is still deployed locally,
+// in which case the reconcile succeeds but the API is not ready.
+func (d *Delegate) setSucceededConditions(api *gio.ApiDefinition) {
+	if !d.HasContext() && len(api.Spec.Contexts) == 0 {
+		conditions.SetSucceeded(&api.Status.Conditions, api.Generation, conditions.Accepted, conditions.Deployed)
+	} else {
+		conditions.SetSucceeded(
+			&api.Status.Conditions, api.Generation,
+			conditions.Accepted, conditions.ResolvedRefs, conditions.ContextReachable,
+			conditions.Synced, conditions.Deployed,
+		)
+	}
+
+	if api.Spec.Context != nil && !d.HasContext() {
+		err := fmt.Errorf("management context %s could not be resolved", api.Spec.Context)
+		conditions.SetFailed(
+			&api.Status.Conditions, api.Generation,
+			conditions.NewError(conditions.ResolvedRefs, conditions.ReasonRefNotFound, err),
+		)
+	}
+
+	if len(api.Status.Drift) > 0 {
+		err := fmt.Errorf("API has drifted from its definition: %s", strings.Join(api.Status.Drift, ", "))
+		conditions.SetFailed(
+			&api.Status.Conditions, api.Generation,
+			conditions.NewError(conditions.Synced, conditions.ReasonDrifted, err),
+		)
+	}
+}
@@ -18,12 +18,21 @@ import (
 	"net/http"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
 
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
 )
 
 func (d *Delegate) CreateOrUpdate(apiDefinition *gio.ApiDefinition) error {
+	if len(apiDefinition.Spec.Contexts) > 0 {
+		return d.createOrUpdateContexts(apiDefinition)
+	}
+
+	if err := d.adopt(apiDefinition); err != nil {
+		return err
+	}
+
 	cp := apiDefinition.DeepCopy()
 
 	spec := &cp.Spec
@@ -51,8 +60,8 @@ func (d *Delegate) CreateOrUpdate(apiDefinition *gio.ApiDefinition) error {
 		apiDefinition.Status.State = spec.State
 	}
 
-	if err := d.deploy(cp); err != nil {
-		return err
+	if err := d.deploy(cp, &apiDefinition.Status.Deployments); err != nil {
+		return conditions.NewDeployError(err)
 	}
 
 	if stateUpdated {
@@ -71,7 +80,7 @@ func (d *Delegate) updateWithContext(api *gio.ApiDefinition) error {
 
 	spec.SetDefinitionContext()
 
-	_, findErr := d.apim.APIs.GetByCrossID(spec.CrossID)
+	_, findErr := d.findApiID(api)
 	if errors.IgnoreNotFound(findErr) != nil {
 		return apim.NewContextError(findErr)
 	}
@@ -97,3 +106,22 @@ func (d *Delegate) updateWithContext(api *gio.ApiDefinition) error {
 
 	return nil
 }
+
+// findApiID returns the ID of the API in APIM, or a not found error if the API does not exist yet.
+func (d *Delegate) findApiID(api *gio.ApiDefinition) (string, error) {
+	item, err := d.apim.APIs.GetByCrossID(api.Spec.CrossID)
+	if err == nil {
+		return item.Id, nil
+	}
+
+	// Adopted APIs created before cross IDs were introduced can only be found by ID
+	if errors.IsNotFound(err) && api.Status.ID != "" {
+		entity, findErr := d.apim.APIs.GetByID(api.Status.ID)
+		if findErr != nil {
+			return "", findErr
+		}
+		return entity.ID, nil
+	}
+
+	return "", err
+}
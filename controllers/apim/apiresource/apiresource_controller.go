@@ -31,6 +31,7 @@ import (
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apiresource/internal"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 )
 
 // Reconciler reconciles a ApiResource object.
@@ -49,12 +50,21 @@ type Reconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "ApiResource", req.NamespacedName)
+	result, err := r.reconcile(ctx, req)
+	span.End(err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	apiResource := &gio.ApiResource{}
 	if err := r.Get(ctx, req.NamespacedName, apiResource); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	tracing.SetGeneration(ctx, apiResource.Generation)
+
 	if err := template.NewResolver(ctx, r.Client, logger, apiResource).Resolve(); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -73,10 +83,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	if reconcileErr == nil {
 		logger.Info("API Resource has been reconciled")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, internal.UpdateStatusSuccess(ctx, r.Client, apiResource)
+	}
+
+	// There was an error reconciling the API Resource
+	if err := internal.UpdateStatusFailure(ctx, r.Client, apiResource, reconcileErr); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	// There was an error reconciling the Management Context
 	return ctrl.Result{}, reconcileErr
 }
 
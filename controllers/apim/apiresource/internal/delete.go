@@ -52,6 +52,20 @@ func Delete(
 		return fmt.Errorf("resource is referenced and will remain")
 	}
 
+	apisV4 := &v1alpha1.ApiV4DefinitionList{}
+	if err := search.FindByFieldReferencing(
+		indexer.ResourceField,
+		refs.NewNamespacedName(resource.Namespace, resource.Name),
+		apisV4,
+	); err != nil {
+		err = fmt.Errorf("an error occurred while checking if the api resource is linked to an api v4 definition: %w", err)
+		return err
+	}
+
+	if len(apisV4.Items) > 0 {
+		return fmt.Errorf("resource is referenced and will remain")
+	}
+
 	util.RemoveFinalizer(resource, keys.ApiResourceFinalizer)
 
 	return client.Update(ctx, resource)
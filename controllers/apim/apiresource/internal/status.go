@@ -0,0 +1,41 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func UpdateStatusSuccess(ctx context.Context, k8s client.Client, instance *gio.ApiResource) error {
+	if instance.IsBeingDeleted() {
+		return nil
+	}
+
+	conditions.SetSucceeded(&instance.Status.Conditions, instance.Generation, conditions.Accepted)
+	return k8s.Status().Update(ctx, instance)
+}
+
+func UpdateStatusFailure(ctx context.Context, k8s client.Client, instance *gio.ApiResource, err error) error {
+	if instance.IsBeingDeleted() {
+		return nil
+	}
+
+	conditions.SetFailed(&instance.Status.Conditions, instance.Generation, err)
+	return k8s.Status().Update(ctx, instance)
+}
@@ -30,9 +30,11 @@ func CreateOrUpdate(
 	instance *v1alpha1.ApiResource,
 ) error {
 	if !util.ContainsFinalizer(instance, keys.ApiResourceFinalizer) {
+		// The instance has been resolved, patching only the finalizer keeps its templates in the cluster
+		base := instance.DeepCopy()
 		util.AddFinalizer(instance, keys.ApiResourceFinalizer)
 
-		if err := k8s.Update(ctx, instance); err != nil {
+		if err := k8s.Patch(ctx, instance, client.MergeFrom(base)); err != nil {
 			err = fmt.Errorf("an error occurs while adding finalizer to the resource: %w", err)
 			return err
 		}
@@ -0,0 +1,156 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiv4definition
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apiv4definition/internal"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/pause"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const requeueAfterTime = time.Second * 5
+
+// Reconciler reconciles a ApiV4Definition object.
+type Reconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Watcher  watch.Interface
+}
+
+// +kubebuilder:rbac:groups=gravitee.io,resources=apiv4definitions,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=gravitee.io,resources=apiv4definitions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gravitee.io,resources=apiv4definitions/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "ApiV4Definition", req.NamespacedName)
+	result, err := r.reconcile(ctx, req)
+	span.End(err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	apiDefinition := &gio.ApiV4Definition{}
+
+	if err := r.Get(ctx, req.NamespacedName, apiDefinition); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	tracing.SetGeneration(ctx, apiDefinition.Generation)
+
+	events := event.NewRecorder(r.Recorder)
+
+	if paused, err := pause.Check(
+		ctx, r.Client, events, apiDefinition, &apiDefinition.Status.Conditions, apiDefinition.ContextRefs()...,
+	); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	delegate := internal.NewDelegate(ctx, r.Client, logger)
+	if err := delegate.ResolveTemplate(apiDefinition); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	delegate.AddDeletionFinalizer(apiDefinition)
+
+	// An API being deleted is released even if its context can not be resolved anymore
+	if err := delegate.ResolveContext(apiDefinition); err != nil {
+		if !apiDefinition.IsBeingDeleted() {
+			logger.Error(err, "Unable to resolve context, no attempt will be made to sync with APIM")
+			if statusErr := delegate.UpdateStatusFailure(apiDefinition, err); statusErr != nil {
+				return ctrl.Result{}, statusErr
+			}
+			return ctrl.Result{RequeueAfter: requeueAfterTime}, err
+		}
+		logger.Info("Unable to resolve context, the API will not be deleted from APIM")
+	}
+
+	var reconcileErr error
+
+	if apiDefinition.IsBeingDeleted() {
+		reconcileErr = events.Record(event.Delete, apiDefinition, func() error {
+			return delegate.Delete(apiDefinition)
+		})
+	} else {
+		reconcileErr = events.Record(event.Update, apiDefinition, func() error {
+			return delegate.CreateOrUpdate(apiDefinition)
+		})
+	}
+
+	if reconcileErr == nil {
+		logger.Info("API v4 definition has been reconciled")
+		return ctrl.Result{}, delegate.UpdateStatusSuccess(apiDefinition)
+	}
+
+	if err := delegate.UpdateStatusFailure(apiDefinition, reconcileErr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if retryAfter, open := apim.IsCircuitOpen(reconcileErr); open {
+		logger.Info("APIM is unavailable, requeuing reconcile", "retryAfter", retryAfter)
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
+	if apim.IsRecoverable(reconcileErr) {
+		logger.Error(reconcileErr, "Requeuing reconcile")
+		return ctrl.Result{RequeueAfter: requeueAfterTime}, reconcileErr
+	}
+
+	logger.Error(reconcileErr, "Aborting reconcile")
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&gio.ApiV4Definition{}).
+		Watches(&gio.ManagementContext{}, r.Watcher.WatchContexts(indexer.ContextField)).
+		Watches(&gio.ApiResource{}, r.Watcher.WatchResources()).
+		Watches(&corev1.ConfigMap{}, r.Watcher.WatchTemplateRefs(indexer.TemplateConfigMapField)).
+		Watches(&corev1.Secret{}, r.Watcher.WatchTemplateRefs(indexer.TemplateSecretField)).
+		Watches(&gio.SecretProvider{}, r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WatchesRawSource(secretprovider.Changes(), r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			watch.TemplateSourceChanged(),
+		))
+
+	if env.Config.NS == "" {
+		builder = builder.Watches(&corev1.Namespace{}, r.Watcher.WatchNamespaces())
+	}
+
+	return builder.Complete(r)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env/template"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const separator = "/"
+
+type Delegate struct {
+	ctx  context.Context
+	k8s  k8s.Client
+	log  logr.Logger
+	apim *apim.APIM
+}
+
+func NewDelegate(ctx context.Context, k8s k8s.Client, log logr.Logger) *Delegate {
+	return &Delegate{
+		ctx, k8s, log, nil,
+	}
+}
+
+func (d *Delegate) ResolveTemplate(api *gio.ApiV4Definition) error {
+	return template.NewResolver(d.ctx, d.k8s, d.log, api).Resolve()
+}
+
+func (d *Delegate) ResolveContext(api *gio.ApiV4Definition) error {
+	ref := api.Spec.Context
+
+	d.log.Info("Resolving API context", "namespace", ref.Namespace, "name", ref.Name)
+
+	apim, err := apim.FromContextRef(d.ctx, d.k8s, *ref)
+	if err != nil {
+		return err
+	}
+
+	d.apim = apim
+	return nil
+}
+
+func (d *Delegate) HasContext() bool {
+	return d.apim != nil
+}
+
+func (d *Delegate) AddDeletionFinalizer(api *gio.ApiV4Definition) {
+	if api.IsMissingDeletionFinalizer() {
+		// Patching only the finalizer keeps the templates of the API definition in the cluster
+		base := api.DeepCopy()
+		util.AddFinalizer(api, keys.ApiV4DefinitionDeletionFinalizer)
+		if err := d.k8s.Patch(d.ctx, api, k8s.MergeFrom(base)); err != nil {
+			d.log.Error(err, "Unable to add deletion finalizer to API v4 definition")
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func (d *Delegate) Delete(api *gio.ApiV4Definition) error {
+	if !util.ContainsFinalizer(api, keys.ApiV4DefinitionDeletionFinalizer) {
+		return nil
+	}
+
+	if d.HasContext() && api.Status.ID != "" {
+		if err := errors.IgnoreNotFound(d.apim.APIsV4.Delete(api.Status.ID)); err != nil {
+			return err
+		}
+	}
+
+	util.RemoveFinalizer(api, keys.ApiV4DefinitionDeletionFinalizer)
+
+	return d.k8s.Update(d.ctx, api)
+}
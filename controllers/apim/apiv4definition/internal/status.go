@@ -0,0 +1,41 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+)
+
+func (d *Delegate) UpdateStatusSuccess(api *gio.ApiV4Definition) error {
+	if api.IsBeingDeleted() {
+		return nil
+	}
+	api.Status.ObservedGeneration = api.ObjectMeta.Generation
+	conditions.SetSucceeded(
+		&api.Status.Conditions, api.Generation,
+		conditions.Accepted, conditions.ResolvedRefs, conditions.ContextReachable, conditions.Synced,
+	)
+	return d.k8s.Status().Update(d.ctx, api)
+}
+
+func (d *Delegate) UpdateStatusFailure(api *gio.ApiV4Definition, err error) error {
+	if api.IsBeingDeleted() {
+		return nil
+	}
+	api.Status.Status = gio.ProcessingStatusFailed
+	conditions.SetFailed(&api.Status.Conditions, api.Generation, err)
+	return d.k8s.Status().Update(d.ctx, api)
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/uuid"
+)
+
+func (d *Delegate) CreateOrUpdate(apiDefinition *gio.ApiV4Definition) error {
+	if !d.HasContext() {
+		return fmt.Errorf("unable to sync API v4 definition without a resolved management context")
+	}
+
+	cp := apiDefinition.DeepCopy()
+
+	spec := &cp.Spec
+	spec.ID = cp.PickID()
+	spec.CrossID = cp.PickCrossID()
+	spec.SetDefinitionContext()
+
+	if err := d.resolveResources(spec); err != nil {
+		d.log.Error(err, "unable to resolve resources")
+		return err
+	}
+
+	preparePlans(spec)
+
+	apiDefinition.Status.EnvID = d.apim.EnvID()
+	apiDefinition.Status.OrgID = d.apim.OrgID()
+	apiDefinition.Status.CrossID = spec.CrossID
+
+	status, err := d.apim.APIsV4.ImportCRD(&spec.Api)
+	if err != nil {
+		return apim.NewContextError(err)
+	}
+
+	apiDefinition.Status.Errors = status.Errors.All()
+
+	if status.Errors.HasSevere() {
+		return apim.NewUnrecoverableError(
+			fmt.Errorf("API v4 definition has been rejected: %s", strings.Join(status.Errors.Severe, ", ")),
+		)
+	}
+
+	apiDefinition.Status.ID = status.ID
+	apiDefinition.Status.State = status.State
+	apiDefinition.Status.Plans = status.Plans
+	apiDefinition.Status.Status = gio.ProcessingStatusCompleted
+
+	return nil
+}
+
+// For each plan, default the name to the plan key and generate
+// a cross ID from the API cross ID and the plan name if not defined.
+func preparePlans(spec *gio.ApiV4DefinitionSpec) {
+	for key, plan := range spec.Plans {
+		if plan.Name == "" {
+			plan.Name = key
+		}
+		if plan.CrossID == "" {
+			plan.CrossID = uuid.FromStrings(spec.CrossID, separator, plan.Name)
+		}
+	}
+}
@@ -22,13 +22,19 @@ import (
 	"time"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
 
 	"github.com/go-logr/logr"
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/application/internal"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/metrics"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/pause"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -55,6 +61,13 @@ type Reconciler struct {
 // +kubebuilder:rbac:groups=gravitee.io,resources=applications/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "Application", req.NamespacedName)
+	result, err := r.reconcile(ctx, req)
+	span.End(err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	application := &gio.Application{}
@@ -63,13 +76,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	tracing.SetGeneration(ctx, application.Generation)
+
+	events := event.NewRecorder(r.Recorder)
+
+	if paused, err := pause.Check(
+		ctx, r.Client, events, application, &application.Status.Conditions, application.ContextRefs()...,
+	); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Dry runs only report the values of the resource as declared, templates unresolved
+	unresolved := application.DeepCopy()
+
 	delegate := internal.NewDelegate(ctx, r.Client, logger)
 	if err := delegate.ResolveTemplate(application); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	events := event.NewRecorder(r.Recorder)
-
 	if application.Spec.Context == nil {
 		logger.Error(fmt.Errorf("no context is provided, no attempt will be made to sync with APIM"), "Aborting reconcile")
 		return ctrl.Result{}, nil
@@ -77,9 +101,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	if err := delegate.ResolveContext(application); err != nil {
 		logger.Error(err, "Unable to resolve context, no attempt will be made to sync with APIM")
+		if statusErr := delegate.UpdateStatusFailure(application, err); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
 		return ctrl.Result{}, err
 	}
 
+	if gio.IsDryRun(application) && !application.IsBeingDeleted() {
+		return dryRun(delegate, events, application, unresolved)
+	}
+
 	if application.IsMissingDeletionFinalizer() {
 		err := delegate.AddDeletionFinalizer(application)
 		if err != nil {
@@ -96,20 +127,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		})
 	} else {
 		reconcileErr = events.Record(event.Update, application, func() error {
-			return delegate.CreateOrUpdate(application)
+			return createOrUpdate(delegate, events, application)
 		})
 	}
 
 	if reconcileErr == nil {
 		logger.Info("Application has been reconciled")
-		return ctrl.Result{}, delegate.UpdateStatusSuccess(application)
+		return ctrl.Result{RequeueAfter: delegate.ResyncPeriod()}, delegate.UpdateStatusSuccess(application)
 	}
 
 	// An error occurred during the reconcile
-	if err := delegate.UpdateStatusFailure(application); err != nil {
+	if err := delegate.UpdateStatusFailure(application, reconcileErr); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if retryAfter, open := apim.IsCircuitOpen(reconcileErr); open {
+		logger.Info("APIM is unavailable, requeuing reconcile", "retryAfter", retryAfter)
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
 	if apim.IsRecoverable(reconcileErr) {
 		logger.Error(reconcileErr, "Requeuing reconcile")
 		return ctrl.Result{RequeueAfter: requeueAfterTime}, reconcileErr
@@ -119,10 +155,86 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return ctrl.Result{}, nil
 }
 
+// Differences found with APIM are either corrected by applying the application again
+// or only reported, depending on the drift policy of the application.
+// The application is not applied again if neither it nor APIM has changed since it was last applied.
+func createOrUpdate(delegate *internal.Delegate, events *event.Recorder, application *gio.Application) error {
+	drift, err := delegate.DetectDrift(application)
+	if err != nil {
+		return err
+	}
+
+	correct := application.Spec.DriftPolicy != gio.DriftPolicyReport
+	if len(drift) > 0 {
+		events.RecordDrift(application, drift, correct)
+		metrics.RecordDrift("Application", application.Namespace, correct)
+	}
+
+	if len(drift) > 0 && !correct {
+		application.Status.Drift = drift
+		return nil
+	}
+
+	hash, err := delegate.AppliedHash(application)
+	if err != nil {
+		return err
+	}
+
+	application.Status.Drift = nil
+	application.Status.DryRun = nil
+
+	// Nothing has changed since the last time the application has been applied
+	if len(drift) == 0 && internal.IsApplied(application, hash) {
+		return nil
+	}
+
+	if err = delegate.CreateOrUpdate(application); err != nil {
+		return err
+	}
+
+	application.Status.AppliedHash = hash
+	return nil
+}
+
+// A dry run reports what would be applied to APIM without applying it.
+// The application is reconciled again once the dry run annotation is removed.
+func dryRun(
+	delegate *internal.Delegate, events *event.Recorder, application, unresolved *gio.Application,
+) (ctrl.Result, error) {
+	if err := delegate.DryRun(application, unresolved); err != nil {
+		if statusErr := delegate.UpdateStatusFailure(application, err); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		if apim.IsRecoverable(err) {
+			return ctrl.Result{RequeueAfter: requeueAfterTime}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	for _, result := range application.Status.DryRun {
+		events.RecordDryRun(application, result.ContextRef.String(), result.Changes)
+	}
+
+	return ctrl.Result{}, delegate.UpdateStatusDryRun(application)
+}
+
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&gio.Application{}).
 		Watches(&gio.ManagementContext{}, r.Watcher.WatchContexts(indexer.AppContextField)).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
-		Complete(r)
+		Watches(&corev1.ConfigMap{}, r.Watcher.WatchTemplateRefs(indexer.TemplateConfigMapField)).
+		Watches(&corev1.Secret{}, r.Watcher.WatchTemplateRefs(indexer.TemplateSecretField)).
+		Watches(&gio.SecretProvider{}, r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WatchesRawSource(secretprovider.Changes(), r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			watch.TemplateSourceChanged(),
+		))
+
+	if env.Config.NS == "" {
+		builder = builder.Watches(&corev1.Namespace{}, r.Watcher.WatchNamespaces())
+	}
+
+	return builder.Complete(r)
 }
@@ -0,0 +1,47 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+)
+
+// adopt takes ownership of an existing APIM application referenced by the adopt annotation,
+// so that it gets updated instead of being created again along with new subscriptions.
+// The application is only adopted once, as its ID is then kept in the status of the application.
+func (d *Delegate) adopt(application *gio.Application) error {
+	id, ok := application.GetAnnotations()[keys.AdoptAnnotation]
+	if !ok || id == "" || application.Status.ID != "" {
+		return nil
+	}
+
+	_, err := d.apim.Applications.GetByID(id)
+	if errors.IsNotFound(err) {
+		return apim.NewUnrecoverableError(fmt.Errorf("unable to adopt application %s: application not found", id))
+	}
+	if err != nil {
+		return apim.NewContextError(err)
+	}
+
+	d.log.Info("Adopting existing application", "id", id)
+	application.Status.ID = id
+
+	return nil
+}
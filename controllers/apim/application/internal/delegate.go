@@ -23,17 +23,10 @@ import (
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
-	coreV1 "k8s.io/api/core/v1"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-const (
-	bearerTokenSecretKey = "bearerToken"
-	usernameSecretKey    = "username"
-	passwordSecretKey    = "password"
-)
-
 type Delegate struct {
 	ctx  context.Context
 	k8s  k8s.Client
@@ -52,22 +45,11 @@ func (d *Delegate) ResolveTemplate(application *gio.Application) error {
 }
 
 func (d *Delegate) ResolveContext(application *gio.Application) error {
-	managementContext := new(gio.ManagementContext)
-
 	ref := application.Spec.Context
-	ns := ref.ToK8sType()
 
 	d.log.Info("Resolving Management context", "namespace", ref.Namespace, "name", ref.Name)
 
-	if err := d.k8s.Get(d.ctx, ns, managementContext); err != nil {
-		return err
-	}
-
-	if err := d.resolveContextSecrets(managementContext); err != nil {
-		return err
-	}
-
-	apim, err := apim.FromContext(d.ctx, managementContext.Spec.Context)
+	apim, err := apim.FromContextRef(d.ctx, d.k8s, *ref)
 	if err != nil {
 		return err
 	}
@@ -81,38 +63,8 @@ func (d *Delegate) HasContext() bool {
 }
 
 func (d *Delegate) AddDeletionFinalizer(application *gio.Application) error {
+	// Patching only the finalizer keeps the templates of the application in the cluster
+	base := application.DeepCopy()
 	util.AddFinalizer(application, keys.ApplicationDeletionFinalizer)
-	return d.k8s.Update(d.ctx, application)
-}
-
-func (d *Delegate) resolveContextSecrets(context *gio.ManagementContext) error {
-	management := context.Spec
-
-	if management.HasSecretRef() {
-		secret := new(coreV1.Secret)
-
-		secretKey := management.SecretRef().ToK8sType()
-		secretKey.Namespace = getSecretNamespace(context)
-
-		if err := d.k8s.Get(d.ctx, secretKey, secret); err != nil {
-			return err
-		}
-
-		bearerToken := string(secret.Data[bearerTokenSecretKey])
-		username := string(secret.Data[usernameSecretKey])
-		password := string(secret.Data[passwordSecretKey])
-
-		management.SetToken(bearerToken)
-		management.SetCredentials(username, password)
-	}
-
-	return nil
-}
-
-func getSecretNamespace(context *gio.ManagementContext) string {
-	secretRef := context.Spec.SecretRef()
-	if secretRef.Namespace != "" {
-		return secretRef.Namespace
-	}
-	return context.Namespace
+	return d.k8s.Patch(d.ctx, application, k8s.MergeFrom(base))
 }
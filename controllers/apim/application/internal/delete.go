@@ -15,7 +15,10 @@
 package internal
 
 import (
+	"net/http"
+
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -28,7 +31,7 @@ func (d *Delegate) Delete(
 		return nil
 	}
 
-	if err := d.apim.Applications.Delete(application.Status.ID); errors.IgnoreNotFound(err) != nil {
+	if err := d.deleteWithContext(application); errors.IgnoreNotFound(err) != nil {
 		return err
 	}
 
@@ -36,3 +39,30 @@ func (d *Delegate) Delete(
 
 	return d.k8s.Update(d.ctx, application)
 }
+
+func (d *Delegate) deleteWithContext(application *gio.Application) error {
+	switch application.Spec.DeletionPolicy.Or(gio.DeletionPolicy(env.Config.DeletionPolicy)) {
+	case gio.DeletionPolicyRetain:
+		d.log.Info("Retaining application in APIM according to deletion policy", "id", application.Status.ID)
+		return nil
+	case gio.DeletionPolicyOrphan:
+		d.log.Info("Orphaning application in APIM according to deletion policy", "id", application.Status.ID)
+		return d.orphan(application)
+	default:
+		return d.apim.Applications.Delete(application.Status.ID)
+	}
+}
+
+// Orphaned applications are given back to APIM by updating their origin.
+func (d *Delegate) orphan(application *gio.Application) error {
+	if application.Status.ID == "" {
+		return nil
+	}
+
+	spec := application.Spec.Application.DeepCopy()
+	spec.ID = application.Status.ID
+	spec.Origin = managementOrigin
+
+	_, err := d.apim.Applications.CreateUpdate(http.MethodPut, spec)
+	return err
+}
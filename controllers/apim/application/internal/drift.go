@@ -0,0 +1,109 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"time"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/diff"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+// The subset of an application that is compared with the application found in APIM to detect drift.
+type applicationState struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Domain      string   `json:"domain,omitempty"`
+	Groups      []string `json:"groups"`
+	Picture     string   `json:"picture,omitempty"`
+	Background  string   `json:"background,omitempty"`
+}
+
+// ResyncPeriod returns the period at which the application should be compared with its state in APIM.
+func (d *Delegate) ResyncPeriod() time.Duration {
+	if !d.HasContext() {
+		return 0
+	}
+	return d.apim.ResyncPeriod()
+}
+
+// DetectDrift returns the differences between the application and the application found in APIM.
+// Drift is only detected if the application has been synced with its current generation,
+// otherwise differences are expected and will be applied anyway.
+func (d *Delegate) DetectDrift(application *gio.Application) ([]string, error) {
+	if d.ResyncPeriod() == 0 || application.Status.ID == "" ||
+		application.Status.ObservedGeneration != application.Generation ||
+		application.Status.Status != gio.ProcessingStatusCompleted {
+		return nil, nil
+	}
+
+	mgmtApp, err := d.apim.Applications.GetByID(application.Status.ID)
+	if errors.IsNotFound(err) {
+		return []string{"application not found in the API Management instance"}, nil
+	}
+	if err != nil {
+		return nil, apim.NewContextError(err)
+	}
+
+	return diff.Compare(desiredApplicationState(application), actualApplicationState(mgmtApp))
+}
+
+// AppliedHash returns the hash of what is applied for the application,
+// including the management context it is synced with as it does not change its generation.
+func (d *Delegate) AppliedHash(application *gio.Application) (string, error) {
+	applied := struct {
+		Spec  gio.ApplicationSpec `json:"spec"`
+		OrgID string              `json:"organizationId,omitempty"`
+		EnvID string              `json:"environmentId,omitempty"`
+	}{Spec: application.Spec}
+
+	if d.HasContext() {
+		applied.OrgID, applied.EnvID = d.apim.OrgID(), d.apim.EnvID()
+	}
+
+	return diff.Hash(applied)
+}
+
+// IsApplied returns true if the current generation of the application has been applied with the given hash.
+func IsApplied(application *gio.Application, hash string) bool {
+	return application.Status.ObservedGeneration == application.Generation &&
+		application.Status.Status == gio.ProcessingStatusCompleted && application.Status.AppliedHash == hash
+}
+
+func desiredApplicationState(application *gio.Application) *applicationState {
+	spec := &application.Spec
+	return &applicationState{
+		Name:        spec.Name,
+		Description: spec.Description,
+		Domain:      spec.Domain,
+		Groups:      spec.Groups,
+		Picture:     spec.Picture,
+		Background:  spec.Background,
+	}
+}
+
+func actualApplicationState(app *model.Application) *applicationState {
+	return &applicationState{
+		Name:        app.Name,
+		Description: app.Description,
+		Domain:      app.Domain,
+		Groups:      app.Groups,
+		Picture:     app.Picture,
+		Background:  app.Background,
+	}
+}
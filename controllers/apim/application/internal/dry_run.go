@@ -0,0 +1,56 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/diff"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+const applicationCreated = "application would be created"
+
+// DryRun reports in the status of the application the differences between the application that would be
+// sent to APIM and the application found in APIM. Metadata are not compared. The values of the fields that
+// are templated in the unresolved application are not reported, as they may have been read from secrets.
+func (d *Delegate) DryRun(application, unresolved *gio.Application) error {
+	spec := application.Spec.DeepCopy()
+	spec.Origin = kubernetesOrigin
+
+	result := gio.DryRunResult{ContextRef: *application.Spec.Context}
+
+	mgmtApp, err := d.apim.Applications.GetByID(application.Status.ID)
+	if errors.IgnoreNotFound(err) != nil {
+		return apim.NewContextError(err)
+	}
+
+	if mgmtApp == nil {
+		result.Changes = []string{applicationCreated}
+	} else {
+		spec.ID = mgmtApp.Id
+		if spec.Settings == nil {
+			spec.Settings = mgmtApp.Settings
+		}
+		desired := desiredApplicationState(&gio.Application{Spec: *spec})
+		templated := desiredApplicationState(unresolved)
+		if result.Changes, err = diff.CompareRedacted(desired, actualApplicationState(mgmtApp), templated); err != nil {
+			return err
+		}
+	}
+
+	application.Status.DryRun = []gio.DryRunResult{result}
+	return nil
+}
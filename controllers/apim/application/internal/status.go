@@ -15,7 +15,12 @@
 package internal
 
 import (
+	"fmt"
+	"strings"
+
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -32,11 +37,38 @@ func (d *Delegate) UpdateStatusSuccess(application *gio.Application) error {
 	}
 
 	application.Status.ObservedGeneration = application.ObjectMeta.Generation
+	conditions.SetSucceeded(
+		&application.Status.Conditions, application.Generation,
+		conditions.Accepted, conditions.ResolvedRefs, conditions.ContextReachable, conditions.Synced,
+	)
+	if len(application.Status.Drift) > 0 {
+		err := fmt.Errorf("application has drifted from its definition: %s", strings.Join(application.Status.Drift, ", "))
+		conditions.SetFailed(
+			&application.Status.Conditions, application.Generation,
+			conditions.NewError(conditions.Synced, conditions.ReasonDrifted, err),
+		)
+	}
+	application.Status.DeepCopyInto(&app.Status)
+	return d.k8s.Status().Update(d.ctx, app)
+}
+
+func (d *Delegate) UpdateStatusFailure(application *gio.Application, err error) error {
+	app := &gio.Application{}
+	if getErr := d.k8s.Get(
+		d.ctx, types.NamespacedName{Namespace: application.Namespace, Name: application.Name}, app,
+	); getErr != nil {
+		return getErr
+	}
+
+	application.Status.Status = gio.ProcessingStatusFailed
+	conditions.SetFailed(&application.Status.Conditions, application.Generation, err)
 	application.Status.DeepCopyInto(&app.Status)
 	return d.k8s.Status().Update(d.ctx, app)
 }
 
-func (d *Delegate) UpdateStatusFailure(application *gio.Application) error {
+// UpdateStatusDryRun records the result of a dry run without advancing the observed generation,
+// the application being accepted but not synced with APIM.
+func (d *Delegate) UpdateStatusDryRun(application *gio.Application) error {
 	app := &gio.Application{}
 	if err := d.k8s.Get(
 		d.ctx, types.NamespacedName{Namespace: application.Namespace, Name: application.Name}, app,
@@ -44,7 +76,12 @@ func (d *Delegate) UpdateStatusFailure(application *gio.Application) error {
 		return err
 	}
 
-	application.Status.Status = gio.ProcessingStatusFailed
+	err := fmt.Errorf("dry run requested with the %s annotation, nothing has been applied", keys.DryRunAnnotation)
+	conditions.SetAccepted(&application.Status.Conditions, application.Generation)
+	conditions.SetFailed(
+		&application.Status.Conditions, application.Generation,
+		conditions.NewError(conditions.Synced, conditions.ReasonDryRun, err),
+	)
 	application.Status.DeepCopyInto(&app.Status)
 	return d.k8s.Status().Update(d.ctx, app)
 }
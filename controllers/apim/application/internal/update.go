@@ -24,7 +24,16 @@ import (
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
 )
 
+const (
+	kubernetesOrigin = "KUBERNETES"
+	managementOrigin = "MANAGEMENT"
+)
+
 func (d *Delegate) CreateOrUpdate(application *gio.Application) error {
+	if err := d.adopt(application); err != nil {
+		return err
+	}
+
 	if err := d.createUpdateApplication(application); err != nil {
 		return err
 	}
@@ -34,7 +43,7 @@ func (d *Delegate) CreateOrUpdate(application *gio.Application) error {
 
 func (d *Delegate) createUpdateApplication(application *gio.Application) error {
 	spec := &application.Spec
-	spec.Origin = "KUBERNETES"
+	spec.Origin = kubernetesOrigin
 	app, err := d.apim.Applications.GetByID(application.Status.ID)
 	if errors.IgnoreNotFound(err) != nil {
 		return apim.NewContextError(err)
@@ -17,9 +17,14 @@ package ingress
 import (
 	"context"
 
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/pause"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	corev1 "k8s.io/api/core/v1"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -30,6 +35,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 	netV1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -52,6 +58,13 @@ type Reconciler struct {
 // Reconcile perform reconciliation logic for Ingress resource that is managed
 // by the operator.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "Ingress", req.NamespacedName)
+	result, err := r.reconcile(ctx, req)
+	span.End(err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	ingress := &netV1.Ingress{}
@@ -59,12 +72,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	tracing.SetGeneration(ctx, ingress.Generation)
+
+	events := e.NewRecorder(r.Recorder)
+
+	if paused, err := pause.Check(ctx, r.Client, events, ingress, nil); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	d := internal.NewDelegate(ctx, r.Client, logger)
 	if err := d.ResolveTemplate(ingress); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	events := e.NewRecorder(r.Recorder)
 	var reconcileErr error
 	if !ingress.DeletionTimestamp.IsZero() {
 		reconcileErr = events.Record(e.Delete, ingress, func() error {
@@ -93,7 +113,11 @@ func (r *Reconciler) ingressClassEventFilter() predicate.Predicate {
 		case *v1alpha1.ApiDefinition:
 			return t.GetAnnotations()[keys.IngressTemplateAnnotation] == "true"
 		case *corev1.Secret:
-			return t.Type == "kubernetes.io/tls"
+			return t.Type == "kubernetes.io/tls" || util.ContainsFinalizer(t, keys.TemplatingFinalizer)
+		case *corev1.ConfigMap, *v1alpha1.SecretProvider:
+			return true
+		case *corev1.Namespace:
+			return true
 		default:
 			return false
 		}
@@ -112,9 +136,8 @@ func (r *Reconciler) ingressClassEventFilter() predicate.Predicate {
 				return false
 			}
 
-			// for some reasons "generation" is not set for the TLS secretes
-			if _, ok := e.ObjectNew.(*corev1.Secret); ok &&
-				e.ObjectOld.GetResourceVersion() != e.ObjectNew.GetResourceVersion() {
+			// "generation" is not set for secrets and config maps
+			if watch.TemplateSourceChanged().Update(e) {
 				return true
 			}
 
@@ -124,6 +147,10 @@ func (r *Reconciler) ingressClassEventFilter() predicate.Predicate {
 				return true
 			}
 
+			if v1alpha1.IsPaused(e.ObjectNew) != v1alpha1.IsPaused(e.ObjectOld) {
+				return true
+			}
+
 			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
@@ -141,11 +168,20 @@ func isGraviteeIngress(ingress *netV1.Ingress) bool {
 
 // SetupWithManager initializes ingress controller manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&netV1.Ingress{}).
 		Owns(&v1alpha1.ApiDefinition{}).
 		Watches(&v1alpha1.ApiDefinition{}, r.Watcher.WatchApiTemplate()).
 		Watches(&corev1.Secret{}, r.Watcher.WatchTLSSecret()).
-		WithEventFilter(r.ingressClassEventFilter()).
-		Complete(r)
+		Watches(&corev1.Secret{}, r.Watcher.WatchTemplateRefs(indexer.TemplateSecretField)).
+		Watches(&v1alpha1.SecretProvider{}, r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WatchesRawSource(secretprovider.Changes(), r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		Watches(&corev1.ConfigMap{}, r.Watcher.WatchTemplateRefs(indexer.TemplateConfigMapField)).
+		WithEventFilter(r.ingressClassEventFilter())
+
+	if env.Config.NS == "" {
+		builder = builder.Watches(&corev1.Namespace{}, r.Watcher.WatchNamespaces())
+	}
+
+	return builder.Complete(r)
 }
@@ -19,7 +19,6 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
-	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
 	"strings"
@@ -30,6 +29,8 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/gateway"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/metrics"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	netV1 "k8s.io/api/networking/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -67,12 +68,19 @@ func (d *Delegate) retrieveIngressListWithTLS(ctx context.Context, ns string) (*
 }
 
 func (d *Delegate) removeKeyFromKeystore(secret *v1.Secret) error {
+	_, span := tracing.Start(d.ctx, "keystore.Remove", tracing.String("k8s.secret.name", secret.Name))
+	err := d.deleteKeystoreEntry(secret)
+	span.End(err)
+	return err
+}
+
+func (d *Delegate) deleteKeystoreEntry(secret *v1.Secret) error {
 	ksc, err := d.getKeystoreCredentials(secret.Namespace)
 	if err != nil {
 		return err
 	}
 
-	pki, _, err := d.generateKeyPair(secret)
+	cert, _, err := d.generateKeyPair(secret)
 	if err != nil {
 		return err
 	}
@@ -83,18 +91,30 @@ func (d *Delegate) removeKeyFromKeystore(secret *v1.Secret) error {
 		return err
 	}
 
-	jks.DeleteEntry(pki.CommonName)
+	jks.DeleteEntry(cert.Subject.CommonName)
 
-	return d.writeToKeyStore(gwKeyStoreSecret, jks, ksc)
+	if err = d.writeToKeyStore(gwKeyStoreSecret, jks, ksc); err != nil {
+		return err
+	}
+
+	metrics.DeleteCertificateExpiry(secret.Namespace, secret.Name, cert.Subject.CommonName)
+	return nil
 }
 
 func (d *Delegate) updateKeyInKeystore(secret *v1.Secret) error {
+	_, span := tracing.Start(d.ctx, "keystore.Update", tracing.String("k8s.secret.name", secret.Name))
+	err := d.setKeystoreEntry(secret)
+	span.End(err)
+	return err
+}
+
+func (d *Delegate) setKeystoreEntry(secret *v1.Secret) error {
 	ksc, err := d.getKeystoreCredentials(secret.Namespace)
 	if err != nil {
 		return err
 	}
 
-	pki, keyPair, err := d.generateKeyPair(secret)
+	cert, keyPair, err := d.generateKeyPair(secret)
 	if err != nil {
 		return err
 	}
@@ -105,11 +125,16 @@ func (d *Delegate) updateKeyInKeystore(secret *v1.Secret) error {
 		return err
 	}
 
-	if err = jks.SetPrivateKeyEntry(pki.CommonName, *keyPair, ksc.pass); err != nil {
+	if err = jks.SetPrivateKeyEntry(cert.Subject.CommonName, *keyPair, ksc.pass); err != nil {
 		return err
 	}
 
-	return d.writeToKeyStore(gwKeyStoreSecret, jks, ksc)
+	if err = d.writeToKeyStore(gwKeyStoreSecret, jks, ksc); err != nil {
+		return err
+	}
+
+	metrics.SetCertificateExpiry(secret.Namespace, secret.Name, cert.Subject.CommonName, cert.NotAfter)
+	return nil
 }
 
 // returns the name of gw keystore and the password to open it.
@@ -231,7 +256,7 @@ func (d *Delegate) unmarshalGatewayConfig(ns string, cfg *gateway.Config) error
 }
 
 // convert K8S tls secret to a keypair.
-func (d *Delegate) generateKeyPair(secret *v1.Secret) (*pkix.Name, *ks.PrivateKeyEntry, error) {
+func (d *Delegate) generateKeyPair(secret *v1.Secret) (*x509.Certificate, *ks.PrivateKeyEntry, error) {
 	// get the key and certificate (The TLS secret must contain keys named tls.crt and tls.key
 	// https://kubernetes.io/docs/concepts/services-networking/ingress/#tls
 	pemKeyBytes, ok := secret.Data["tls.key"]
@@ -278,7 +303,7 @@ func (d *Delegate) generateKeyPair(secret *v1.Secret) (*pkix.Name, *ks.PrivateKe
 		},
 	}
 
-	return &cert.Subject, pke, nil
+	return cert, pke, nil
 }
 
 func (d *Delegate) readKeyStore(nn *types.NamespacedName, ksc *keystoreCredentials) (*v1.Secret, *ks.KeyStore, error) {
@@ -314,5 +339,10 @@ func (d *Delegate) writeToKeyStore(ksSecret *v1.Secret, jks *ks.KeyStore, ksc *k
 
 	ksSecret.Data[ksc.key] = b.Bytes()
 
-	return d.k8s.Update(d.ctx, ksSecret)
+	if err = d.k8s.Update(d.ctx, ksSecret); err != nil {
+		return err
+	}
+
+	metrics.SetKeystoreEntries(ksSecret.Namespace, ksSecret.Name, len(jks.Aliases()))
+	return nil
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/search"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
@@ -50,6 +51,22 @@ func Delete(
 		return fmt.Errorf("can not delete %s because %d api(s) relying on this context", instance.Name, len(apis.Items))
 	}
 
+	apisV4 := &gio.ApiV4DefinitionList{}
+	if err := search.New(ctx, client).FindByFieldReferencing(
+		indexer.ContextField,
+		refs.NewNamespacedName(instance.Namespace, instance.Name),
+		apisV4,
+	); err != nil {
+		err = fmt.Errorf(
+			"an error occurred while checking if the management context is linked to an api v4 definition: %w", err,
+		)
+		return err
+	}
+
+	if len(apisV4.Items) > 0 {
+		return fmt.Errorf("can not delete %s because %d api(s) relying on this context", instance.Name, len(apisV4.Items))
+	}
+
 	apps := &gio.ApplicationList{}
 	err := search.New(ctx, client).FindByFieldReferencing(
 		indexer.AppContextField,
@@ -68,6 +85,7 @@ func Delete(
 	}
 
 	util.RemoveFinalizer(instance, keys.ManagementContextFinalizer)
+	apim.Invalidate(*instance.GetNamespacedName())
 
 	return client.Update(ctx, instance)
 }
@@ -0,0 +1,62 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Probe sends an authenticated request to the management API of the context,
+// recording the time of the probe and the version of APIM in the status of the context if it succeeds.
+// Failed probes are counted by the circuit breaker of the context.
+func Probe(ctx context.Context, k8s client.Client, instance *gio.ManagementContext) error {
+	client, err := apim.FromContextRef(ctx, k8s, *instance.GetNamespacedName())
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Environments.GetByID(instance.Spec.EnvId)
+	if errors.IsNotFound(err) {
+		return conditions.NewError(
+			conditions.ContextReachable,
+			conditions.ReasonUnknownEnv,
+			fmt.Errorf("environment %s not found in organization %s", instance.Spec.EnvId, instance.Spec.OrgId),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Management APIs that do not expose their node information are still reachable
+	node, err := client.Node.Get()
+	if errors.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if node != nil {
+		instance.Status.APIMVersion = node.Version.Version
+	}
+
+	now := metav1.Now()
+	instance.Status.LastSuccessTime = &now
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	"golang.org/x/net/context"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,13 +32,20 @@ func CreateOrUpdate(
 ) error {
 	// We only add a finalizer to our ManagementContexts to keep track of their deletion
 	if !util.ContainsFinalizer(instance, keys.ManagementContextFinalizer) {
+		// Patching only the finalizer keeps the templates of the context in the cluster
+		base := instance.DeepCopy()
 		util.AddFinalizer(instance, keys.ManagementContextFinalizer)
 
-		if err := k8s.Update(ctx, instance); err != nil {
+		if err := k8s.Patch(ctx, instance, client.MergeFrom(base)); err != nil {
 			err = fmt.Errorf("an error occurred while adding finalizer to the management context: %w", err)
 			return err
 		}
 	}
 
-	return nil
+	// Requests to APIM are suspended by the circuit breaker of the context after too many failures
+	if err := apim.CheckBreaker(instance); err != nil {
+		return err
+	}
+
+	return Probe(ctx, k8s, instance)
 }
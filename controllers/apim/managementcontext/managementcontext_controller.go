@@ -18,21 +18,35 @@ package managementcontext
 
 import (
 	"context"
+	"time"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env/template"
 
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/managementcontext/internal"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/pause"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	ctrlEvent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 )
 
 // Reconciler reconciles a ManagementContext object.
@@ -46,18 +60,35 @@ type Reconciler struct {
 // +kubebuilder:rbac:groups=gravitee.io,resources=managementcontexts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gravitee.io,resources=managementcontexts/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gravitee.io,resources=managementcontexts/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "ManagementContext", req.NamespacedName)
+	result, err := r.reconcile(ctx, req)
+	span.End(err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	managementContext := &gio.ManagementContext{}
 	if err := r.Get(ctx, req.NamespacedName, managementContext); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	tracing.SetGeneration(ctx, managementContext.Generation)
+
+	events := event.NewRecorder(r.Recorder)
+
+	if paused, err := pause.Check(
+		ctx, r.Client, events, managementContext, &managementContext.Status.Conditions,
+	); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := template.NewResolver(ctx, r.Client, logger, managementContext).Resolve(); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	events := event.NewRecorder(r.Recorder)
 	var reconcileErr error
 	if managementContext.IsBeingDeleted() {
 		reconcileErr = events.Record(event.Delete, managementContext, func() error {
@@ -71,17 +102,69 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	if reconcileErr == nil {
 		logger.Info("Management context has been reconciled")
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: probeInterval()}, internal.UpdateStatusSuccess(ctx, r.Client, managementContext)
 	}
 
 	// There was an error reconciling the Management Context
+	if err := internal.UpdateStatusFailure(ctx, r.Client, managementContext, reconcileErr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if retryAfter, open := apim.IsCircuitOpen(reconcileErr); open {
+		logger.Info("APIM is unavailable", "retryAfter", retryAfter)
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
+	// Failed probes are retried at the usual interval, the circuit breaker handling repeated failures
+	if conditionType, _ := conditions.FromError(reconcileErr); conditionType == conditions.ContextReachable &&
+		probeInterval() > 0 {
+		logger.Info("APIM is unreachable", "error", reconcileErr.Error())
+		return ctrl.Result{RequeueAfter: probeInterval()}, nil
+	}
+
 	return ctrl.Result{}, reconcileErr
 }
 
+// The management API of each context is probed periodically to report its reachability.
+func probeInterval() time.Duration {
+	return time.Duration(env.Config.ContextProbeInterval) * time.Second
+}
+
+// How many breaker changes can be pending before the next ones are dropped.
+const breakerEventsSize = 64
+
 // SetupWithManager sets up the controller with the Manager.
+// Management contexts are also reconciled when their circuit breaker opens or closes
+// so that the availability of APIM is reported in their status.
+// The channel is only read once the controller is started, which never happens on a replica
+// that is not the leader, changes are therefore dropped rather than waited for when it is full.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	breakerEvents := make(chan ctrlEvent.GenericEvent, breakerEventsSize)
+	apim.OnBreakerChange(func(ref refs.NamespacedName, _ bool) {
+		select {
+		case breakerEvents <- ctrlEvent.GenericEvent{
+			Object: &gio.ManagementContext{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace}},
+		}:
+		default:
+		}
+	})
+
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&gio.ManagementContext{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
-		Complete(r)
+		WatchesRawSource(&source.Channel{Source: breakerEvents}, &handler.EnqueueRequestForObject{}).
+		Watches(&corev1.ConfigMap{}, r.Watcher.WatchTemplateRefs(indexer.TemplateConfigMapField)).
+		Watches(&corev1.Secret{}, r.Watcher.WatchTemplateRefs(indexer.TemplateSecretField)).
+		Watches(&gio.SecretProvider{}, r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WatchesRawSource(secretprovider.Changes(), r.Watcher.WatchTemplateRefs(indexer.TemplateProviderField)).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			watch.TemplateSourceChanged(),
+		))
+
+	if env.Config.NS == "" {
+		builder = builder.Watches(&corev1.Namespace{}, r.Watcher.WatchNamespaces())
+	}
+
+	return builder.Complete(r)
 }
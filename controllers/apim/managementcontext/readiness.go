@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package managementcontext
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// ReadinessCheck returns a check failing while the management API of any context is known to be unreachable.
+// Contexts that have not been probed yet are not taken into account.
+func ReadinessCheck(k8s client.Reader) healthz.Checker {
+	return func(req *http.Request) error {
+		contexts := &gio.ManagementContextList{}
+		if err := k8s.List(req.Context(), contexts); err != nil {
+			return err
+		}
+
+		var unreachable []string
+		for i := range contexts.Items {
+			context := &contexts.Items[i]
+			if meta.IsStatusConditionFalse(context.Status.Conditions, conditions.ContextReachable) {
+				unreachable = append(unreachable, context.GetNamespacedName().String())
+			}
+		}
+
+		if len(unreachable) > 0 {
+			return fmt.Errorf("management contexts are unreachable: %s", strings.Join(unreachable, ", "))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managementcontext
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Readiness check", func() {
+	newContext := func(name string, reachable ...metav1.ConditionStatus) *gio.ManagementContext {
+		context := &gio.ManagementContext{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		for _, status := range reachable {
+			context.Status.Conditions = append(context.Status.Conditions, metav1.Condition{
+				Type:   conditions.ContextReachable,
+				Status: status,
+				Reason: conditions.ReasonUnreachable,
+			})
+		}
+		return context
+	}
+
+	check := func(objects ...runtime.Object) error {
+		scheme := runtime.NewScheme()
+		Expect(gio.AddToScheme(scheme)).To(Succeed())
+		k8s := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+		return ReadinessCheck(k8s)(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	}
+
+	It("Should be ready when all contexts are reachable or not probed yet", func() {
+		Expect(check(
+			newContext("dev", metav1.ConditionTrue),
+			newContext("staging"),
+		)).To(Succeed())
+	})
+
+	It("Should not be ready when a context is unreachable", func() {
+		err := check(
+			newContext("dev", metav1.ConditionTrue),
+			newContext("staging", metav1.ConditionFalse),
+		)
+		Expect(err).To(MatchError("management contexts are unreachable: default/staging"))
+	})
+})
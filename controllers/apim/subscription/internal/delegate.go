@@ -0,0 +1,140 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	kErrors "k8s.io/apimachinery/pkg/api/errors"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type Delegate struct {
+	ctx  context.Context
+	k8s  k8s.Client
+	log  logr.Logger
+	apim *apim.APIM
+	// The context of the API the subscription is managed in.
+	apiContext refs.NamespacedName
+}
+
+func NewDelegate(ctx context.Context, k8s k8s.Client, log logr.Logger) *Delegate {
+	return &Delegate{
+		ctx, k8s, log, nil, refs.NamespacedName{},
+	}
+}
+
+// ResolveContext resolves the management context of the subscribed API definition,
+// subscriptions being always managed in the environment the API has been synced with.
+// When the subscription is being deleted and its API definition is gone, no context is resolved
+// and the subscription is left to be closed along with the API plans in APIM.
+func (d *Delegate) ResolveContext(subscription *gio.Subscription) error {
+	api, err := d.getApiDefinition(subscription)
+	if kErrors.IsNotFound(err) && subscription.IsBeingDeleted() {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ref, err := d.findApiContext(subscription, api)
+	if err != nil {
+		return err
+	}
+
+	if ref == nil {
+		if subscription.IsBeingDeleted() {
+			return nil
+		}
+		return fmt.Errorf("API definition %s has no management context", subscription.ApiRef())
+	}
+
+	d.log.Info("Resolving API context", "namespace", ref.Namespace, "name", ref.Name)
+
+	apim, err := apim.FromContextRef(d.ctx, d.k8s, *ref)
+	if err != nil {
+		return err
+	}
+
+	d.apim = apim
+	d.apiContext = *ref
+	return nil
+}
+
+// findApiContext returns the context of the API the subscription is managed in. An API synced with
+// multiple contexts is subscribed in the context of the application if it is one of them,
+// or in its primary context otherwise, so that the subscription is rejected if the environments differ.
+func (d *Delegate) findApiContext(
+	subscription *gio.Subscription, api *gio.ApiDefinition,
+) (*refs.NamespacedName, error) {
+	if len(api.Spec.Contexts) == 0 {
+		return api.Spec.Context, nil
+	}
+
+	app, err := d.getApplication(subscription)
+	if err != nil && !subscription.IsBeingDeleted() {
+		return nil, err
+	}
+
+	for _, target := range api.Spec.Contexts {
+		if app != nil && app.Spec.Context != nil && target.ContextRef == *app.Spec.Context {
+			ref := target.ContextRef
+			return &ref, nil
+		}
+	}
+
+	return &api.Spec.Contexts[0].ContextRef, nil
+}
+
+// apiID returns the ID of the API in the context the subscription is managed in.
+func (d *Delegate) apiID(api *gio.ApiDefinition) string {
+	if status := api.ContextStatus(d.apiContext); status != nil {
+		return status.ID
+	}
+	return api.Status.ID
+}
+
+func (d *Delegate) HasContext() bool {
+	return d.apim != nil
+}
+
+func (d *Delegate) AddDeletionFinalizer(subscription *gio.Subscription) error {
+	util.AddFinalizer(subscription, keys.SubscriptionDeletionFinalizer)
+	return d.k8s.Update(d.ctx, subscription)
+}
+
+func (d *Delegate) getApiDefinition(subscription *gio.Subscription) (*gio.ApiDefinition, error) {
+	api := new(gio.ApiDefinition)
+	if err := d.k8s.Get(d.ctx, subscription.ApiRef().ToK8sType(), api); err != nil {
+		return nil, err
+	}
+	return api, nil
+}
+
+func (d *Delegate) getApplication(subscription *gio.Subscription) (*gio.Application, error) {
+	app := new(gio.Application)
+	if err := d.k8s.Get(d.ctx, subscription.AppRef().ToK8sType(), app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	apimModel "github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	coreV1 "k8s.io/api/core/v1"
+	kErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	apiKeySecretKey = "apiKey"
+	managedByKey    = "managed-by"
+	gioTypeKey      = "gio-type"
+)
+
+// Writes the most recent valid API key of the subscription into a Secret owned by the subscription.
+// When the subscription has no valid key anymore (e.g. all keys have been revoked), the Secret is deleted
+// so that revocation is propagated to the workloads consuming it.
+func (d *Delegate) syncApiKeySecret(subscription *gio.Subscription, plan *apimModel.Plan) error {
+	secretName := subscription.GetApiKeySecretName()
+
+	if subscription.Status.ApiKeySecret != "" && subscription.Status.ApiKeySecret != secretName {
+		if err := d.deleteApiKeySecret(subscription); err != nil {
+			return err
+		}
+	}
+
+	if plan.Security != apimModel.PlanSecurityApiKey {
+		return d.deleteApiKeySecret(subscription)
+	}
+
+	apiKeys, err := d.apim.Subscriptions.GetApiKeys(subscription.Status.ApiID, subscription.Status.ID)
+	if err != nil {
+		return apim.NewContextError(err)
+	}
+
+	apiKey := findLatestValidKey(apiKeys)
+	if apiKey == nil {
+		d.log.Info("No valid API key found for subscription, deleting secret", "secret", secretName)
+		return d.deleteApiKeySecret(subscription)
+	}
+
+	secret := &coreV1.Secret{}
+	secret.Name = secretName
+	secret.Namespace = subscription.Namespace
+
+	if _, err = util.CreateOrUpdate(d.ctx, d.k8s, secret, func() error {
+		if !secret.CreationTimestamp.IsZero() && !metav1.IsControlledBy(secret, subscription) {
+			return fmt.Errorf("secret %s already exists and is not managed by subscription %s", secretName, subscription.Name)
+		}
+
+		secret.Labels = map[string]string{
+			managedByKey: keys.CrdGroup,
+			gioTypeKey:   keys.CrdSubscriptionResource + "." + keys.CrdGroup,
+		}
+		secret.Data = map[string][]byte{
+			apiKeySecretKey: []byte(apiKey.Key),
+		}
+
+		return util.SetControllerReference(subscription, secret, d.k8s.Scheme())
+	}); err != nil {
+		return err
+	}
+
+	subscription.Status.ApiKeySecret = secretName
+
+	return nil
+}
+
+func (d *Delegate) deleteApiKeySecret(subscription *gio.Subscription) error {
+	if subscription.Status.ApiKeySecret == "" {
+		return nil
+	}
+
+	secret := &coreV1.Secret{}
+	key := types.NamespacedName{Name: subscription.Status.ApiKeySecret, Namespace: subscription.Namespace}
+
+	err := d.k8s.Get(d.ctx, key, secret)
+	if kErrors.IsNotFound(err) {
+		subscription.Status.ApiKeySecret = ""
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if metav1.IsControlledBy(secret, subscription) {
+		if err = d.k8s.Delete(d.ctx, secret); err != nil && !kErrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	subscription.Status.ApiKeySecret = ""
+
+	return nil
+}
+
+// Returns the most recently created key that has not been revoked, paused or expired.
+func findLatestValidKey(apiKeys []apimModel.ApiKeyEntity) *apimModel.ApiKeyEntity {
+	now := metav1.Now().UnixMilli()
+
+	var latest *apimModel.ApiKeyEntity
+	for i := range apiKeys {
+		key := &apiKeys[i]
+		if !key.IsValid(now) {
+			continue
+		}
+		if latest == nil || key.CreatedAt > latest.CreatedAt {
+			latest = key
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (d *Delegate) UpdateStatusSuccess(subscription *gio.Subscription) error {
+	if subscription.IsBeingDeleted() {
+		return nil
+	}
+
+	sub := &gio.Subscription{}
+	if err := d.k8s.Get(
+		d.ctx, types.NamespacedName{Namespace: subscription.Namespace, Name: subscription.Name}, sub,
+	); err != nil {
+		return err
+	}
+
+	subscription.Status.ObservedGeneration = subscription.ObjectMeta.Generation
+	conditions.SetSucceeded(
+		&subscription.Status.Conditions, subscription.Generation,
+		conditions.Accepted, conditions.ResolvedRefs, conditions.ContextReachable, conditions.Synced,
+	)
+	subscription.Status.DeepCopyInto(&sub.Status)
+	return d.k8s.Status().Update(d.ctx, sub)
+}
+
+func (d *Delegate) UpdateStatusFailure(subscription *gio.Subscription, err error) error {
+	if subscription.IsBeingDeleted() {
+		return nil
+	}
+
+	sub := &gio.Subscription{}
+	if getErr := d.k8s.Get(
+		d.ctx, types.NamespacedName{Namespace: subscription.Namespace, Name: subscription.Name}, sub,
+	); getErr != nil {
+		return getErr
+	}
+
+	subscription.Status.Status = gio.ProcessingStatusFailed
+	conditions.SetFailed(&subscription.Status.Conditions, subscription.Generation, err)
+	subscription.Status.DeepCopyInto(&sub.Status)
+	return d.k8s.Status().Update(d.ctx, sub)
+}
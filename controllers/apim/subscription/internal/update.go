@@ -0,0 +1,148 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	apimModel "github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+func (d *Delegate) CreateOrUpdate(subscription *gio.Subscription) error {
+	api, err := d.getApiDefinition(subscription)
+	if err != nil {
+		return err
+	}
+
+	apiID := d.apiID(api)
+	if apiID == "" {
+		return fmt.Errorf("API definition %s has not been synced with APIM yet", subscription.ApiRef())
+	}
+
+	app, err := d.getApplication(subscription)
+	if err != nil {
+		return err
+	}
+
+	if app.Status.ID == "" {
+		return fmt.Errorf("application %s has not been synced with APIM yet", subscription.AppRef())
+	}
+
+	if app.Status.EnvID != d.apim.EnvID() {
+		return fmt.Errorf(
+			"application %s and API definition %s are not synced with the same environment",
+			subscription.AppRef(), subscription.ApiRef(),
+		)
+	}
+
+	plan, err := d.findPlan(apiID, subscription.Spec.Plan)
+	if err != nil {
+		return err
+	}
+
+	if err = d.subscribe(subscription, apiID, app, plan.Id); err != nil {
+		return err
+	}
+
+	if err = d.syncApiKeySecret(subscription, plan); err != nil {
+		return err
+	}
+
+	subscription.Status.Status = gio.ProcessingStatusCompleted
+
+	return nil
+}
+
+// Ensures that an active subscription exists in APIM for the given API, application and plan,
+// closing the previous one if the subscription target has changed.
+func (d *Delegate) subscribe(
+	subscription *gio.Subscription, apiID string, app *gio.Application, planID string,
+) error {
+	mgmtSubscription, err := d.getActiveSubscription(subscription)
+	if errors.IgnoreNotFound(err) != nil {
+		return apim.NewContextError(err)
+	}
+
+	if err == nil {
+		if subscription.Status.ApiID == apiID &&
+			mgmtSubscription.PlanID() == planID &&
+			mgmtSubscription.ApplicationID() == app.Status.ID {
+			subscription.Status.SubscriptionStatus = string(mgmtSubscription.Status)
+			return nil
+		}
+
+		d.log.Info("Subscription target has changed, closing previous subscription", "id", mgmtSubscription.Id)
+		if closeErr := d.apim.Subscriptions.Close(subscription.Status.ApiID, mgmtSubscription.Id); closeErr != nil {
+			return apim.NewContextError(closeErr)
+		}
+	}
+
+	mgmtSubscription, err = d.apim.Subscriptions.Subscribe(apiID, app.Status.ID, planID)
+	if err != nil {
+		return apim.NewContextError(err)
+	}
+
+	subscription.Status.OrgID = d.apim.OrgID()
+	subscription.Status.EnvID = d.apim.EnvID()
+	subscription.Status.ID = mgmtSubscription.Id
+	subscription.Status.ApiID = apiID
+	subscription.Status.PlanID = planID
+	subscription.Status.AppID = app.Status.ID
+	subscription.Status.SubscriptionStatus = string(mgmtSubscription.Status)
+
+	return nil
+}
+
+// Finds the plan referenced by the subscription, using the plan cross ID if defined
+// or the plan name otherwise.
+func (d *Delegate) findPlan(apiID string, ref gio.PlanRef) (*apimModel.Plan, error) {
+	mgmtApi, err := d.apim.APIs.GetByID(apiID)
+	if err != nil {
+		return nil, apim.NewContextError(err)
+	}
+
+	for _, plan := range mgmtApi.Plans {
+		if ref.CrossID != "" && plan.CrossId == ref.CrossID {
+			return plan, nil
+		}
+		if ref.CrossID == "" && plan.Name == ref.Name {
+			return plan, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to find plan %+v in API %s", ref, apiID)
+}
+
+// Returns the subscription previously created in APIM, or a not found error
+// if the subscription does not exist anymore or has been closed.
+func (d *Delegate) getActiveSubscription(subscription *gio.Subscription) (*apimModel.Subscription, error) {
+	if subscription.Status.ID == "" {
+		return nil, errors.NewNotFoundError()
+	}
+
+	mgmtSubscription, err := d.apim.Subscriptions.GetByID(subscription.Status.ApiID, subscription.Status.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mgmtSubscription.IsClosed() {
+		return nil, errors.NewNotFoundError()
+	}
+
+	return mgmtSubscription, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/subscription/internal"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/pause"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	requeueAfterTime = time.Second * 5
+	// API keys can be renewed or revoked in APIM without any notification being sent to the operator,
+	// subscriptions holding API keys are therefore periodically synced to keep their Secret up to date.
+	apiKeysSyncPeriod = time.Minute
+)
+
+// Reconciler reconciles a Subscription object.
+type Reconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Watcher  watch.Interface
+}
+
+// +kubebuilder:rbac:groups=gravitee.io,resources=subscriptions,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=gravitee.io,resources=subscriptions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gravitee.io,resources=subscriptions/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gravitee.io,resources=apidefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gravitee.io,resources=applications,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "Subscription", req.NamespacedName)
+	result, err := r.reconcile(ctx, req)
+	span.End(err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	subscription := &gio.Subscription{}
+
+	if err := r.Get(ctx, req.NamespacedName, subscription); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	tracing.SetGeneration(ctx, subscription.Generation)
+
+	delegate := internal.NewDelegate(ctx, r.Client, logger)
+	events := event.NewRecorder(r.Recorder)
+
+	if paused, err := pause.Check(
+		ctx, r.Client, events, subscription, &subscription.Status.Conditions,
+	); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := delegate.ResolveContext(subscription); err != nil {
+		logger.Error(err, "Unable to resolve context, no attempt will be made to sync with APIM")
+		if statusErr := delegate.UpdateStatusFailure(subscription, err); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{RequeueAfter: requeueAfterTime}, err
+	}
+
+	if subscription.IsMissingDeletionFinalizer() {
+		if err := delegate.AddDeletionFinalizer(subscription); err != nil {
+			logger.Error(err, "Unable to add deletion finalizer to Subscription")
+			return ctrl.Result{}, err
+		}
+	}
+
+	var reconcileErr error
+
+	if subscription.IsBeingDeleted() {
+		reconcileErr = events.Record(event.Delete, subscription, func() error {
+			return delegate.Delete(subscription)
+		})
+	} else {
+		reconcileErr = events.Record(event.Update, subscription, func() error {
+			return delegate.CreateOrUpdate(subscription)
+		})
+	}
+
+	if reconcileErr == nil {
+		logger.Info("Subscription has been reconciled")
+		if subscription.Status.ApiKeySecret != "" {
+			return ctrl.Result{RequeueAfter: apiKeysSyncPeriod}, delegate.UpdateStatusSuccess(subscription)
+		}
+		return ctrl.Result{}, delegate.UpdateStatusSuccess(subscription)
+	}
+
+	// An error occurred during the reconcile
+	if err := delegate.UpdateStatusFailure(subscription, reconcileErr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if retryAfter, open := apim.IsCircuitOpen(reconcileErr); open {
+		logger.Info("APIM is unavailable, requeuing reconcile", "retryAfter", retryAfter)
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
+	if apim.IsRecoverable(reconcileErr) {
+		logger.Error(reconcileErr, "Requeuing reconcile")
+		return ctrl.Result{RequeueAfter: requeueAfterTime}, reconcileErr
+	}
+
+	logger.Error(reconcileErr, "Aborting reconcile")
+	return ctrl.Result{}, nil
+}
+
+// The generation predicate is not applied to the API key secret, secrets having no generation,
+// so that a secret edited or deleted out of band is written again right away.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	changed := builder.WithPredicates(
+		predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+	)
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&gio.Subscription{}, changed).
+		Owns(&coreV1.Secret{}).
+		Watches(&gio.ApiDefinition{}, r.Watcher.WatchSubscriptionRefs(indexer.SubscriptionApiField), changed).
+		Watches(&gio.Application{}, r.Watcher.WatchSubscriptionRefs(indexer.SubscriptionAppField), changed)
+
+	if env.Config.NS == "" {
+		b = b.Watches(&coreV1.Namespace{}, r.Watcher.WatchNamespaces())
+	}
+
+	return b.Complete(r)
+}
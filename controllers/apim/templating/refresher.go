@@ -0,0 +1,33 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templating
+
+import (
+	"context"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/search"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewRefresher returns a runnable refreshing the values read from secret providers
+// for as long as they are used in the templates of some resources.
+func NewRefresher(k8s client.Client) *secretprovider.Refresher {
+	return secretprovider.NewRefresher(k8s, func(ctx context.Context, ref refs.NamespacedName) (bool, error) {
+		return search.New(ctx, k8s).IsReferencedByTemplates(indexer.TemplateProviderField, ref)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templating
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env/template"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/search"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Source describes a kind of object that can be used in templates.
+type Source struct {
+	Kind  string
+	Field indexer.IndexField
+	New   func() client.Object
+	Refs  func(runtime.Object) []refs.NamespacedName
+}
+
+var (
+	ConfigMaps = Source{
+		Kind:  "ConfigMap",
+		Field: indexer.TemplateConfigMapField,
+		New:   func() client.Object { return &corev1.ConfigMap{} },
+		Refs:  template.ConfigMapRefs,
+	}
+	Secrets = Source{
+		Kind:  "Secret",
+		Field: indexer.TemplateSecretField,
+		New:   func() client.Object { return &corev1.Secret{} },
+		Refs:  template.SecretRefs,
+	}
+)
+
+// Reconciler releases the config maps and secrets holding the templating finalizer
+// once they are not used in the templates of any resource.
+type Reconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Source Source
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, r.Source.Kind, req.NamespacedName)
+	err := r.reconcile(ctx, req)
+	span.End(err)
+	return ctrl.Result{}, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	obj := r.Source.New()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !util.ContainsFinalizer(obj, keys.TemplatingFinalizer) {
+		return nil
+	}
+
+	ref := refs.NewNamespacedName(obj.GetNamespace(), obj.GetName())
+	referenced, err := search.New(ctx, r.Client).IsReferencedByTemplates(r.Source.Field, ref)
+	if err != nil || referenced {
+		return err
+	}
+
+	log.FromContext(ctx).Info("object is not used in any template, removing finalizer", "kind", r.Source.Kind)
+	base, _ := obj.DeepCopyObject().(client.Object)
+	util.RemoveFinalizer(obj, keys.TemplatingFinalizer)
+	return r.Patch(ctx, obj, client.MergeFrom(base))
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	sources := watch.TemplateSources(r.Source.Refs)
+	templated := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return util.ContainsFinalizer(obj, keys.TemplatingFinalizer)
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(r.Source.Kind)+"-templating").
+		For(r.Source.New(), builder.WithPredicates(templated)).
+		Watches(&v1alpha1.ApiDefinition{}, sources).
+		Watches(&v1alpha1.ApiV4Definition{}, sources).
+		Watches(&v1alpha1.Application{}, sources).
+		Watches(&v1alpha1.ApiResource{}, sources).
+		Watches(&v1alpha1.ManagementContext{}, sources).
+		Watches(&netv1.Ingress{}, sources).
+		Complete(r)
+}
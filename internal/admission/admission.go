@@ -0,0 +1,74 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission implements the validating admission webhooks of gravitee.io resources,
+// rejecting invalid objects before they are stored instead of failing on reconcile.
+package admission
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env/template"
+	kErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+)
+
+// SetupWithManager registers the validating webhooks of all gravitee.io resources on the manager webhook server.
+func SetupWithManager(mgr ctrl.Manager) error {
+	k8s := mgr.GetClient()
+
+	validators := []struct {
+		obj       runtime.Object
+		validator admission.CustomValidator
+	}{
+		{&gio.ApiDefinition{}, &apiDefinitionValidator{k8s: k8s}},
+		{&gio.Application{}, &applicationValidator{}},
+		{&gio.ManagementContext{}, &managementContextValidator{}},
+		{&gio.ApiResource{}, &apiResourceValidator{}},
+		{&gio.SecretProvider{}, &secretProviderValidator{}},
+	}
+
+	for _, v := range validators {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(v.obj).WithValidator(v.validator).Complete(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isBeingDeleted is used to let updates through when an object is being deleted,
+// so that finalizers can be removed from objects stored before the webhooks were enabled.
+func isBeingDeleted(obj metav1.Object) bool {
+	return !obj.GetDeletionTimestamp().IsZero()
+}
+
+func validateTemplates(obj runtime.Object) field.ErrorList {
+	if err := template.Validate(obj); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec"), "", err.Error())}
+	}
+	return nil
+}
+
+func toError(kind string, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return kErrors.NewInvalid(schema.GroupKind{Group: gio.GroupVersion.Group, Kind: kind}, name, errs)
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v2 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v2"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/search"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const apiDefinitionKind = "ApiDefinition"
+
+var planSecurityTypes = []string{"KEY_LESS", "API_KEY", "JWT", "OAUTH2"}
+
+// +kubebuilder:webhook:path=/validate-gravitee-io-v1alpha1-apidefinition,mutating=false,failurePolicy=fail,sideEffects=None,groups=gravitee.io,resources=apidefinitions,verbs=create;update,versions=v1alpha1,name=vapidefinition.gravitee.io,admissionReviewVersions=v1
+
+type apiDefinitionValidator struct {
+	k8s client.Client
+}
+
+var _ admission.CustomValidator = &apiDefinitionValidator{}
+
+func (v *apiDefinitionValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	api, ok := obj.(*gio.ApiDefinition)
+	if !ok {
+		return nil, fmt.Errorf("expected an API definition but got %T", obj)
+	}
+	return nil, v.validate(ctx, api)
+}
+
+func (v *apiDefinitionValidator) ValidateUpdate(
+	ctx context.Context, _, newObj runtime.Object,
+) (admission.Warnings, error) {
+	api, ok := newObj.(*gio.ApiDefinition)
+	if !ok {
+		return nil, fmt.Errorf("expected an API definition but got %T", newObj)
+	}
+	if isBeingDeleted(api) {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, api)
+}
+
+func (v *apiDefinitionValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *apiDefinitionValidator) validate(ctx context.Context, api *gio.ApiDefinition) error {
+	spec := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	if !api.Spec.IsLocal && len(api.ContextRefs()) == 0 {
+		errs = append(errs, field.Required(spec.Child("contextRef"), "an API that is not local must reference a context"))
+	}
+
+	errs = append(errs, validateContexts(spec.Child("contexts"), api)...)
+	errs = append(errs, validatePlans(spec.Child("plans"), api.Spec.Plans)...)
+
+	pathErrs, err := v.validateVirtualHosts(ctx, spec.Child("proxy", "virtual_hosts"), api)
+	if err != nil {
+		return err
+	}
+	errs = append(errs, pathErrs...)
+
+	errs = append(errs, validateTemplates(api)...)
+
+	return toError(apiDefinitionKind, api.Name, errs)
+}
+
+func validateContexts(path *field.Path, api *gio.ApiDefinition) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(api.Spec.Contexts) == 0 {
+		return errs
+	}
+
+	if api.Spec.Context != nil {
+		errs = append(errs, field.Forbidden(path, "contexts and contextRef are mutually exclusive"))
+	}
+
+	if api.Spec.IsLocal {
+		errs = append(errs, field.Forbidden(path, "an API synced with multiple contexts can not be local"))
+	}
+
+	refs := make(map[string]bool)
+	for i, target := range api.Spec.Contexts {
+		ref := target.ContextRef.String()
+		if refs[ref] {
+			errs = append(errs, field.Duplicate(path.Index(i).Child("contextRef"), ref))
+		}
+		refs[ref] = true
+	}
+
+	return errs
+}
+
+func validatePlans(path *field.Path, plans []*v2.Plan) field.ErrorList {
+	errs := field.ErrorList{}
+	names := make(map[string]bool)
+
+	for i, plan := range plans {
+		if plan == nil {
+			errs = append(errs, field.Required(path.Index(i), "a plan can not be null"))
+			continue
+		}
+
+		if plan.Plan != nil {
+			if names[plan.Name] {
+				errs = append(errs, field.Duplicate(path.Index(i).Child("name"), plan.Name))
+			}
+			names[plan.Name] = true
+		}
+
+		if !contains(planSecurityTypes, plan.Security) {
+			errs = append(errs, field.NotSupported(path.Index(i).Child("security"), plan.Security, planSecurityTypes))
+		}
+	}
+
+	return errs
+}
+
+// Virtual host paths must be unique across the APIs synced with the same context,
+// otherwise the management API rejects the API on import.
+func (v *apiDefinitionValidator) validateVirtualHosts(
+	ctx context.Context, path *field.Path, api *gio.ApiDefinition,
+) (field.ErrorList, error) {
+	if api.Spec.Proxy == nil || isTemplate(api) {
+		return nil, nil
+	}
+
+	taken := make(map[string]string)
+	for _, ref := range api.ContextRefs() {
+		apis := &gio.ApiDefinitionList{}
+		if err := search.New(ctx, v.k8s).FindByFieldReferencing(indexer.ContextField, ref, apis); err != nil {
+			return nil, err
+		}
+
+		for i := range apis.Items {
+			other := &apis.Items[i]
+			if other.Namespace == api.Namespace && other.Name == api.Name || other.Spec.Proxy == nil || isTemplate(other) {
+				continue
+			}
+			for _, vh := range other.Spec.Proxy.VirtualHosts {
+				taken[virtualHostKey(vh)] = other.GetNamespacedName().String()
+			}
+		}
+	}
+
+	errs := field.ErrorList{}
+	for i, vh := range api.Spec.Proxy.VirtualHosts {
+		if owner, found := taken[virtualHostKey(vh)]; found {
+			errs = append(errs, field.Invalid(
+				path.Index(i).Child("path"), vh.Path, fmt.Sprintf("path is already used by API %s", owner),
+			))
+		}
+	}
+
+	return errs, nil
+}
+
+// Ingress templates are never synced with the management API on their own.
+func isTemplate(api *gio.ApiDefinition) bool {
+	return api.GetAnnotations()[keys.IngressTemplateAnnotation] == "true"
+}
+
+func virtualHostKey(vh *v2.VirtualHost) string {
+	return vh.Host + strings.TrimSuffix(vh.Path, "/")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const apiResourceKind = "ApiResource"
+
+// +kubebuilder:webhook:path=/validate-gravitee-io-v1alpha1-apiresource,mutating=false,failurePolicy=fail,sideEffects=None,groups=gravitee.io,resources=apiresources,verbs=create;update,versions=v1alpha1,name=vapiresource.gravitee.io,admissionReviewVersions=v1
+
+type apiResourceValidator struct{}
+
+var _ admission.CustomValidator = &apiResourceValidator{}
+
+func (v *apiResourceValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	resource, ok := obj.(*gio.ApiResource)
+	if !ok {
+		return nil, fmt.Errorf("expected an API resource but got %T", obj)
+	}
+	return nil, toError(apiResourceKind, resource.Name, validateTemplates(resource))
+}
+
+func (v *apiResourceValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	resource, ok := newObj.(*gio.ApiResource)
+	if !ok {
+		return nil, fmt.Errorf("expected an API resource but got %T", newObj)
+	}
+	if isBeingDeleted(resource) {
+		return nil, nil
+	}
+	return nil, toError(apiResourceKind, resource.Name, validateTemplates(resource))
+}
+
+func (v *apiResourceValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
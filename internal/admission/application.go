@@ -0,0 +1,59 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const applicationKind = "Application"
+
+// +kubebuilder:webhook:path=/validate-gravitee-io-v1alpha1-application,mutating=false,failurePolicy=fail,sideEffects=None,groups=gravitee.io,resources=applications,verbs=create;update,versions=v1alpha1,name=vapplication.gravitee.io,admissionReviewVersions=v1
+
+type applicationValidator struct{}
+
+var _ admission.CustomValidator = &applicationValidator{}
+
+func (v *applicationValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	app, ok := obj.(*gio.Application)
+	if !ok {
+		return nil, fmt.Errorf("expected an application but got %T", obj)
+	}
+	return nil, v.validate(app)
+}
+
+func (v *applicationValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	app, ok := newObj.(*gio.Application)
+	if !ok {
+		return nil, fmt.Errorf("expected an application but got %T", newObj)
+	}
+	if isBeingDeleted(app) {
+		return nil, nil
+	}
+	return nil, v.validate(app)
+}
+
+func (v *applicationValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *applicationValidator) validate(app *gio.Application) error {
+	return toError(applicationKind, app.Name, validateTemplates(app))
+}
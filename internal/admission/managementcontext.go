@@ -0,0 +1,111 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const managementContextKind = "ManagementContext"
+
+// +kubebuilder:webhook:path=/validate-gravitee-io-v1alpha1-managementcontext,mutating=false,failurePolicy=fail,sideEffects=None,groups=gravitee.io,resources=managementcontexts,verbs=create;update,versions=v1alpha1,name=vmanagementcontext.gravitee.io,admissionReviewVersions=v1
+
+type managementContextValidator struct{}
+
+var _ admission.CustomValidator = &managementContextValidator{}
+
+func (v *managementContextValidator) ValidateCreate(
+	_ context.Context, obj runtime.Object,
+) (admission.Warnings, error) {
+	mCtx, ok := obj.(*gio.ManagementContext)
+	if !ok {
+		return nil, fmt.Errorf("expected a management context but got %T", obj)
+	}
+	return nil, v.validate(mCtx)
+}
+
+func (v *managementContextValidator) ValidateUpdate(
+	_ context.Context, _, newObj runtime.Object,
+) (admission.Warnings, error) {
+	mCtx, ok := newObj.(*gio.ManagementContext)
+	if !ok {
+		return nil, fmt.Errorf("expected a management context but got %T", newObj)
+	}
+	if isBeingDeleted(mCtx) {
+		return nil, nil
+	}
+	return nil, v.validate(mCtx)
+}
+
+func (v *managementContextValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *managementContextValidator) validate(mCtx *gio.ManagementContext) error {
+	errs := field.ErrorList{}
+
+	if auth := mCtx.Spec.Auth; auth != nil && auth.BearerToken != "" && auth.Credentials != nil {
+		errs = append(errs, field.Forbidden(
+			field.NewPath("spec", "auth"), "bearerToken and credentials are mutually exclusive",
+		))
+	}
+
+	errs = append(errs, validateOAuth2(mCtx.Spec.Auth)...)
+
+	if tls := mCtx.Spec.TLS; tls != nil && tls.CA != nil {
+		caPath := field.NewPath("spec", "tls", "ca")
+		if tls.CA.SecretRef != nil && tls.CA.ConfigMapRef != nil {
+			errs = append(errs, field.Forbidden(caPath, "secretRef and configMapRef are mutually exclusive"))
+		}
+		if tls.CA.SecretRef == nil && tls.CA.ConfigMapRef == nil {
+			errs = append(errs, field.Required(caPath, "one of secretRef or configMapRef is required"))
+		}
+	}
+
+	errs = append(errs, validateTemplates(mCtx)...)
+
+	return toError(managementContextKind, mCtx.Name, errs)
+}
+
+func validateOAuth2(auth *management.Auth) field.ErrorList {
+	errs := field.ErrorList{}
+	if auth == nil || auth.OAuth2 == nil {
+		return errs
+	}
+
+	authPath := field.NewPath("spec", "auth")
+	if auth.BearerToken != "" || auth.Credentials != nil || auth.SecretRef != nil {
+		errs = append(errs, field.Forbidden(
+			authPath.Child("oauth2"), "oauth2 cannot be used with bearerToken, credentials or secretRef",
+		))
+	}
+
+	oauth2Path := authPath.Child("oauth2")
+	if auth.OAuth2.ClientSecret != "" && auth.OAuth2.ClientSecretRef != nil {
+		errs = append(errs, field.Forbidden(oauth2Path, "clientSecret and clientSecretRef are mutually exclusive"))
+	}
+	if auth.OAuth2.ClientSecret == "" && auth.OAuth2.ClientSecretRef == nil {
+		errs = append(errs, field.Required(oauth2Path, "one of clientSecret or clientSecretRef is required"))
+	}
+
+	return errs
+}
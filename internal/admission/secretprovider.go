@@ -0,0 +1,83 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const secretProviderKind = "SecretProvider"
+
+// +kubebuilder:webhook:path=/validate-gravitee-io-v1alpha1-secretprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=gravitee.io,resources=secretproviders,verbs=create;update,versions=v1alpha1,name=vsecretprovider.gravitee.io,admissionReviewVersions=v1
+
+type secretProviderValidator struct{}
+
+var _ admission.CustomValidator = &secretProviderValidator{}
+
+func (v *secretProviderValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	provider, ok := obj.(*gio.SecretProvider)
+	if !ok {
+		return nil, fmt.Errorf("expected a secret provider but got %T", obj)
+	}
+	return nil, toError(secretProviderKind, provider.Name, validateSecretProvider(provider))
+}
+
+func (v *secretProviderValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	provider, ok := newObj.(*gio.SecretProvider)
+	if !ok {
+		return nil, fmt.Errorf("expected a secret provider but got %T", newObj)
+	}
+	if isBeingDeleted(provider) {
+		return nil, nil
+	}
+	return nil, toError(secretProviderKind, provider.Name, validateSecretProvider(provider))
+}
+
+func (v *secretProviderValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateSecretProvider(provider *gio.SecretProvider) field.ErrorList {
+	spec := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	switch {
+	case provider.Spec.Vault == nil && provider.Spec.HTTP == nil:
+		errs = append(errs, field.Required(spec, "one of vault or http must be set"))
+	case provider.Spec.Vault != nil && provider.Spec.HTTP != nil:
+		errs = append(errs, field.Forbidden(spec, "only one of vault or http can be set"))
+	case provider.Spec.Vault != nil:
+		errs = append(errs, validateURL(spec.Child("vault", "address"), provider.Spec.Vault.Address)...)
+	default:
+		errs = append(errs, validateURL(spec.Child("http", "url"), provider.Spec.HTTP.URL)...)
+	}
+
+	return errs
+}
+
+// validateURL rejects URLs whose host is not allowed for secret providers in the operator configuration.
+func validateURL(path *field.Path, value string) field.ErrorList {
+	if err := secretprovider.CheckURL(value); err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	return nil
+}
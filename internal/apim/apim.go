@@ -16,6 +16,8 @@ package apim
 
 import (
 	"context"
+	netHttp "net/http"
+	"time"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/client"
@@ -25,11 +27,17 @@ import (
 
 // APIM wraps services needed to sync resources with a given environment on a Gravitee.io APIM instance.
 type APIM struct {
-	APIs         *service.APIs
-	Applications *service.Applications
-
-	orgID string
-	envID string
+	APIs          *service.APIs
+	APIsV4        *service.APIsV4
+	Applications  *service.Applications
+	Subscriptions *service.Subscriptions
+	Environments  *service.Environments
+	Node          *service.Node
+
+	orgID        string
+	envID        string
+	resyncPeriod time.Duration
+	client       *client.Client
 }
 
 // EnvID returns the environment ID of the current managed APIM instance.
@@ -42,8 +50,23 @@ func (apim *APIM) OrgID() string {
 	return apim.orgID
 }
 
+// ResyncPeriod returns the period at which resources should be compared with their state in APIM,
+// or zero if drift detection is disabled for the current managed APIM instance.
+func (apim *APIM) ResyncPeriod() time.Duration {
+	return apim.resyncPeriod
+}
+
 // FromContext returns a new APIM instance from a given reconcile context and management context.
 func FromContext(ctx context.Context, managementContext management.Context) (*APIM, error) {
+	transport := http.NewTransport(toHttpAuth(managementContext), nil)
+	return fromContext(ctx, managementContext, transport)
+}
+
+func fromContext(
+	ctx context.Context,
+	managementContext management.Context,
+	transport netHttp.RoundTripper,
+) (*APIM, error) {
 	orgID, envID := managementContext.OrgId, managementContext.EnvId
 	urls, err := client.NewURLs(managementContext.BaseUrl, orgID, envID)
 	if err != nil {
@@ -51,26 +74,53 @@ func FromContext(ctx context.Context, managementContext management.Context) (*AP
 	}
 
 	client := &client.Client{
-		HTTP: http.NewClient(ctx, toHttpAuth(managementContext)),
+		HTTP: http.NewClientWithTransport(ctx, transport),
 		URLs: urls,
 	}
 
 	return &APIM{
-		APIs:         service.NewAPIs(client),
-		Applications: service.NewApplications(client),
-		orgID:        orgID,
-		envID:        envID,
+		APIs:          service.NewAPIs(client),
+		APIsV4:        service.NewAPIsV4(client),
+		Applications:  service.NewApplications(client),
+		Subscriptions: service.NewSubscriptions(client),
+		Environments:  service.NewEnvironments(client),
+		Node:          service.NewNode(client),
+		orgID:         orgID,
+		envID:         envID,
+		resyncPeriod:  resyncPeriod(managementContext),
+		client:        client,
 	}, nil
 }
 
+func resyncPeriod(managementContext management.Context) time.Duration {
+	if managementContext.ResyncPeriod == nil {
+		return 0
+	}
+	return managementContext.ResyncPeriod.Duration
+}
+
 func toHttpAuth(management management.Context) *http.Auth {
 	if !management.HasAuthentication() {
 		return nil
 	}
 
 	return &http.Auth{
-		Basic: toBasicAuth(management.Auth),
-		Token: toBearer(management.Auth),
+		Basic:  toBasicAuth(management.Auth),
+		Token:  toBearer(management.Auth),
+		OAuth2: toOAuth2(management.Auth),
+	}
+}
+
+func toOAuth2(auth *management.Auth) *http.OAuth2 {
+	if auth == nil || auth.OAuth2 == nil {
+		return nil
+	}
+
+	return &http.OAuth2{
+		TokenURL:     auth.OAuth2.TokenURL,
+		ClientID:     auth.OAuth2.ClientID,
+		ClientSecret: auth.OAuth2.ClientSecret,
+		Scopes:       auth.OAuth2.Scopes,
 	}
 }
 
@@ -0,0 +1,96 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apim
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/http"
+)
+
+// BreakerListener is called when the circuit breaker of a management context opens or closes.
+type BreakerListener = func(ref refs.NamespacedName, open bool)
+
+// Circuit breakers are shared by all the clients created for a management context
+// so that failures are tracked across reconciles and across resources.
+var breakers = struct {
+	sync.Mutex
+	byContext map[string]*http.Breaker
+	listeners []BreakerListener
+}{byContext: make(map[string]*http.Breaker)}
+
+// OnBreakerChange registers a listener called each time the circuit breaker
+// of a management context opens or closes.
+func OnBreakerChange(listener BreakerListener) {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	breakers.listeners = append(breakers.listeners, listener)
+}
+
+// CheckBreaker returns a CircuitOpenError if requests to the APIM instance
+// of the management context are currently suspended.
+func CheckBreaker(context *gio.ManagementContext) error {
+	return breakerOf(context).Err()
+}
+
+func breakerOf(context *gio.ManagementContext) *http.Breaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	ref := *context.GetNamespacedName()
+	// The base URL is part of the key so that a context pointing to a new instance starts with a closed breaker
+	key := ref.String() + "|" + context.Spec.BaseUrl
+	if breaker, ok := breakers.byContext[key]; ok {
+		return breaker
+	}
+
+	host := context.Spec.BaseUrl
+	if baseUrl, err := url.Parse(host); err == nil {
+		host = baseUrl.Host
+	}
+
+	breaker := http.NewBreaker(
+		host,
+		env.Config.BreakerThreshold,
+		time.Duration(env.Config.BreakerCooldown)*time.Second,
+		func(open bool) { notifyBreakerChange(ref, open) },
+	)
+	breakers.byContext[key] = breaker
+
+	return breaker
+}
+
+func notifyBreakerChange(ref refs.NamespacedName, open bool) {
+	breakers.Lock()
+	listeners := breakers.listeners
+	breakers.Unlock()
+
+	for _, listener := range listeners {
+		listener(ref, open)
+	}
+}
+
+// IsCircuitOpen returns true if err was caused by the circuit breaker of a management context,
+// along with the time after which requests will be attempted again.
+func IsCircuitOpen(err error) (time.Duration, bool) {
+	return errors.IsCircuitOpen(err)
+}
@@ -19,8 +19,10 @@ import (
 )
 
 const (
-	orgPath = "/management/organizations/"
-	envPath = "/environments/"
+	basePath  = "/management"
+	orgPath   = "/management/organizations/"
+	orgV2Path = "/management/v2/organizations/"
+	envPath   = "/environments/"
 )
 
 // Client is the client for a given instance of the Gravitee.io Management API
@@ -35,8 +37,17 @@ type Client struct {
 
 // URLs contains URLs targeting the organization and environment of the client.
 type URLs struct {
-	Org *http.URL
-	Env *http.URL
+	// Base targets the management API itself, regardless of the organization and environment
+	Base *http.URL
+	Org  *http.URL
+	Env  *http.URL
+	// EnvV2 targets the environment on the v2 Management API, used to manage v4 APIs
+	EnvV2 *http.URL
+}
+
+// BaseTarget returns a new URL with the given path appended to the management API URL.
+func (client *Client) BaseTarget(path string) *http.URL {
+	return client.URLs.Base.WithPath(path)
 }
 
 // EnvTarget returns a new URL with the given path appended to the environment URL.
@@ -44,13 +55,18 @@ func (client *Client) EnvTarget(path string) *http.URL {
 	return client.URLs.Env.WithPath(path)
 }
 
+// EnvV2Target returns a new URL with the given path appended to the v2 Management API environment URL.
+func (client *Client) EnvV2Target(path string) *http.URL {
+	return client.URLs.EnvV2.WithPath(path)
+}
+
 // OrgTarget returns a new URL with the given path appended to the organization URL.
 func (client *Client) OrgTarget(path string) *http.URL {
 	return client.URLs.Org.WithPath(path)
 }
 
 // NewURLs returns a new URLs instance for the given base URL
-// with Org path initialized from the given orgID and Env path initialized from the given envID.
+// with Org path initialized from the given orgID and Env paths initialized from the given envID.
 func NewURLs(baseUrl string, orgID, envID string) (*URLs, error) {
 	base, err := http.NewURL(baseUrl)
 	if err != nil {
@@ -59,6 +75,7 @@ func NewURLs(baseUrl string, orgID, envID string) (*URLs, error) {
 
 	org := base.WithPath(orgPath, orgID)
 	env := org.WithPath(envPath, envID)
+	envV2 := base.WithPath(orgV2Path, orgID).WithPath(envPath, envID)
 
-	return &URLs{org, env}, nil
+	return &URLs{base.WithPath(basePath), org, env, envV2}, nil
 }
@@ -0,0 +1,188 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apim
+
+import (
+	"context"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/http"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	bearerTokenSecretKey  = "bearerToken"
+	usernameSecretKey     = "username"
+	passwordSecretKey     = "password"
+	clientSecretSecretKey = "clientSecret"
+	caBundleKey           = "ca.crt"
+)
+
+// FromContextRef resolves the management context referenced by ref, including the credentials
+// stored in its secret reference if any, and returns a new APIM instance targeting this context.
+// The transport used to send requests is shared by all the instances targeting the same context.
+func FromContextRef(ctx context.Context, client k8s.Client, ref refs.NamespacedName) (*APIM, error) {
+	// The span is not carried by the context of the instance, its requests belonging to the caller
+	_, span := tracing.Start(ctx, "apim.ResolveContext", tracing.String("gko.context", ref.String()))
+	instance, err := fromContextRef(ctx, client, ref)
+	span.End(err)
+	return instance, err
+}
+
+func fromContextRef(ctx context.Context, client k8s.Client, ref refs.NamespacedName) (*APIM, error) {
+	managementContext := new(gio.ManagementContext)
+
+	if err := client.Get(ctx, ref.ToK8sType(), managementContext); err != nil {
+		return nil, err
+	}
+
+	if err := resolveContextSecrets(ctx, client, managementContext); err != nil {
+		return nil, err
+	}
+
+	tlsMaterial, err := resolveContextTLS(ctx, client, managementContext)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := transportOf(managementContext, tlsMaterial)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := fromContext(ctx, managementContext.Spec.Context, entry.transport)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.client.HTTP.
+		WithName(ref.String()).
+		WithBreaker(breakerOf(managementContext)).
+		WithLimits(entry.limits...)
+
+	return instance, nil
+}
+
+func resolveContextSecrets(ctx context.Context, client k8s.Client, context *gio.ManagementContext) error {
+	management := context.Spec
+
+	if management.HasSecretRef() {
+		secret := new(coreV1.Secret)
+
+		secretKey := management.SecretRef().ToK8sType()
+		secretKey.Namespace = getSecretNamespace(context)
+
+		if err := client.Get(ctx, secretKey, secret); err != nil {
+			return err
+		}
+
+		bearerToken := string(secret.Data[bearerTokenSecretKey])
+		username := string(secret.Data[usernameSecretKey])
+		password := string(secret.Data[passwordSecretKey])
+
+		management.SetToken(bearerToken)
+		management.SetCredentials(username, password)
+	}
+
+	if management.HasOAuth2() && management.Auth.OAuth2.ClientSecretRef != nil {
+		secret := new(coreV1.Secret)
+		if err := client.Get(ctx, namespacedIn(context, management.Auth.OAuth2.ClientSecretRef), secret); err != nil {
+			return err
+		}
+
+		management.SetClientSecret(string(secret.Data[clientSecretSecretKey]))
+	}
+
+	return nil
+}
+
+// resolveContextTLS reads the TLS material referenced by the context. The material is read
+// each time a client is created so that rotated certificates are used on the next reconcile.
+func resolveContextTLS(ctx context.Context, client k8s.Client, context *gio.ManagementContext) (*http.TLS, error) {
+	tlsMaterial := new(http.TLS)
+	if !context.Spec.HasTLS() {
+		return tlsMaterial, nil
+	}
+
+	if ca := context.Spec.TLS.CA; ca != nil {
+		bundle, err := resolveCABundle(ctx, client, context, ca)
+		if err != nil {
+			return nil, err
+		}
+		tlsMaterial.CA = bundle
+	}
+
+	if ref := context.Spec.TLS.ClientCertSecretRef; ref != nil {
+		secret := new(coreV1.Secret)
+		if err := client.Get(ctx, namespacedIn(context, ref), secret); err != nil {
+			return nil, err
+		}
+		tlsMaterial.Cert = secret.Data[coreV1.TLSCertKey]
+		tlsMaterial.Key = secret.Data[coreV1.TLSPrivateKeyKey]
+	}
+
+	return tlsMaterial, nil
+}
+
+func resolveCABundle(
+	ctx context.Context,
+	client k8s.Client,
+	context *gio.ManagementContext,
+	ca *management.CABundle,
+) ([]byte, error) {
+	key := ca.Key
+	if key == "" {
+		key = caBundleKey
+	}
+
+	if ca.SecretRef != nil {
+		secret := new(coreV1.Secret)
+		if err := client.Get(ctx, namespacedIn(context, ca.SecretRef), secret); err != nil {
+			return nil, err
+		}
+		return secret.Data[key], nil
+	}
+
+	if ca.ConfigMapRef != nil {
+		configMap := new(coreV1.ConfigMap)
+		if err := client.Get(ctx, namespacedIn(context, ca.ConfigMapRef), configMap); err != nil {
+			return nil, err
+		}
+		return []byte(configMap.Data[key]), nil
+	}
+
+	return nil, nil
+}
+
+func namespacedIn(context *gio.ManagementContext, ref *refs.NamespacedName) types.NamespacedName {
+	key := ref.ToK8sType()
+	if key.Namespace == "" {
+		key.Namespace = context.Namespace
+	}
+	return key
+}
+
+func getSecretNamespace(context *gio.ManagementContext) string {
+	secretRef := context.Spec.SecretRef()
+	if secretRef.Namespace != "" {
+		return secretRef.Namespace
+	}
+	return context.Namespace
+}
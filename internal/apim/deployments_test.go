@@ -0,0 +1,94 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apim
+
+import (
+	"context"
+	"encoding/json"
+	netHttp "net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+var _ = Describe("Deployments", func() {
+	const apiPath = "/management/organizations/DEFAULT/environments/DEFAULT/apis/api-id"
+
+	var server *httptest.Server
+	var label string
+	var events string
+
+	BeforeEach(func() {
+		label = ""
+		events = `[]`
+		server = httptest.NewServer(netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+			defer GinkgoRecover()
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == netHttp.MethodPost && r.URL.Path == apiPath+"/deploy":
+				deployment := &model.ApiDeployment{}
+				Expect(json.NewDecoder(r.Body).Decode(deployment)).To(Succeed())
+				label = deployment.DeploymentLabel
+				_, _ = w.Write([]byte(`{"id":"api-id"}`))
+			case r.Method == netHttp.MethodGet && r.URL.Path == apiPath+"/events":
+				Expect(r.URL.Query().Get("type")).To(Equal(model.EventTypePublishApi))
+				_, _ = w.Write([]byte(events))
+			default:
+				Fail("unexpected request to " + r.URL.Path)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newAPIM := func() *APIM {
+		apim, err := FromContext(context.Background(), management.Context{
+			BaseUrl: server.URL,
+			OrgId:   "DEFAULT",
+			EnvId:   "DEFAULT",
+			Auth:    &management.Auth{BearerToken: "token"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return apim
+	}
+
+	It("Should deploy an API with a label", func() {
+		Expect(newAPIM().APIs.Deploy("api-id", "generation 2")).To(Succeed())
+		Expect(label).To(Equal("generation 2"))
+	})
+
+	It("Should find the last deployment of an API", func() {
+		events = `[
+			{"id":"1","type":"PUBLISH_API","properties":{"deployment_number":"9","deployment_label":"generation 1"}},
+			{"id":"2","type":"PUBLISH_API","properties":{"deployment_number":"10","deployment_label":"generation 2"}}
+		]`
+		event, err := newAPIM().APIs.LastDeployment("api-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(event.DeploymentNumber()).To(Equal(10))
+		Expect(event.DeploymentLabel()).To(Equal("generation 2"))
+	})
+
+	It("Should not find the deployment of an API that has never been deployed", func() {
+		_, err := newAPIM().APIs.LastDeployment("api-id")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,80 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apim
+
+import (
+	"context"
+	netHttp "net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+var _ = Describe("Environments", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/management/organizations/DEFAULT/environments"))
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(netHttp.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id":"DEFAULT","name":"Default","hrids":["default"]},{"id":"uuid","hrids":["staging"]}]`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newAPIM := func(token string) *APIM {
+		apim, err := FromContext(context.Background(), management.Context{
+			BaseUrl: server.URL,
+			OrgId:   "DEFAULT",
+			EnvId:   "DEFAULT",
+			Auth:    &management.Auth{BearerToken: token},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return apim
+	}
+
+	DescribeTable("Should find an environment by ID or human readable ID",
+		func(envID, expected string) {
+			env, err := newAPIM("token").Environments.GetByID(envID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env.Id).To(Equal(expected))
+		},
+		Entry("with an ID", "DEFAULT", "DEFAULT"),
+		Entry("with a human readable ID", "staging", "uuid"),
+	)
+
+	It("Should not find an unknown environment", func() {
+		_, err := newAPIM("token").Environments.GetByID("unknown")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should fail with invalid credentials", func() {
+		_, err := newAPIM("invalid").Environments.GetByID("DEFAULT")
+		Expect(errors.IsServerError(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("status 401")))
+	})
+})
@@ -31,10 +31,24 @@ func (e ContextError) Is(err error) bool {
 	return errors.As(err, new(ContextError))
 }
 
+func (e ContextError) Unwrap() error {
+	return e.error
+}
+
 func NewContextError(err error) error {
 	return ContextError{err}
 }
 
+// UnrecoverableError is returned when APIM rejects a resource for reasons
+// that can only be fixed by updating the resource (e.g. an invalid definition).
+type UnrecoverableError struct {
+	error
+}
+
+func NewUnrecoverableError(err error) error {
+	return UnrecoverableError{err}
+}
+
 func IsRecoverable(err error) bool {
 	errs := make([]error, 0)
 
@@ -55,6 +69,10 @@ func IsRecoverable(err error) bool {
 }
 
 func isRecoverable(err error) bool {
+	if errors.As(err, new(UnrecoverableError)) {
+		return false
+	}
+
 	contextError := &ContextError{}
 	if errors.As(err, contextError) {
 		cause := contextError.error
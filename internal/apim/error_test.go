@@ -29,6 +29,7 @@ var errRaw = fmt.Errorf("raw error")
 var errNotFound = ContextError{apimError.ServerError{StatusCode: 404}}
 var errBadRequest = ContextError{apimError.ServerError{StatusCode: 400}}
 var errUnauthorized = ContextError{apimError.ServerError{StatusCode: 401}}
+var errUnrecoverable = UnrecoverableError{errRaw}
 
 var _ = Describe("Errors", func() {
 	DescribeTable("recoverable errors",
@@ -39,6 +40,7 @@ var _ = Describe("Errors", func() {
 		Entry("With not found error", errNotFound, true),
 		Entry("With unauthorized error", errUnauthorized, false),
 		Entry("With bad request", errBadRequest, false),
+		Entry("With unrecoverable error", errUnrecoverable, false),
 	)
 
 	DescribeTable("context error",
@@ -16,10 +16,15 @@ package model
 
 type ApiEntity struct {
 	ID                string             `json:"id"`
+	CrossID           string             `json:"crossId,omitempty"`
 	Name              string             `json:"name"`
+	Version           string             `json:"version"`
+	Description       string             `json:"description"`
 	State             string             `json:"state"`
 	Visibility        string             `json:"visibility"`
 	ApiLifecycleState string             `json:"lifecycle_state"`
+	Tags              []string           `json:"tags,omitempty"`
+	Labels            []string           `json:"labels,omitempty"`
 	Plans             []*Plan            `json:"plans"`
 	Resources         []*Resource        `json:"resources,omitempty"`
 	DefinitionContext *DefinitionContext `json:"definition_context,omitempty"`
@@ -47,6 +52,13 @@ func NewKubernetesContext() *DefinitionContext {
 	}
 }
 
+func NewManagementContext() *DefinitionContext {
+	return &DefinitionContext{
+		Origin: OriginManagement,
+		Mode:   ModeFullyManaged,
+	}
+}
+
 type ApiListItem struct {
 	Id                string `json:"id"`
 	Name              string `json:"name"`
@@ -74,6 +86,8 @@ type Resource struct {
 
 type PlanSecurityType string
 
+const PlanSecurityApiKey PlanSecurityType = "API_KEY"
+
 type PlanStatus string
 
 type ApiDeployment struct {
@@ -15,6 +15,20 @@
 package model
 
 type ApiKeyEntity struct {
-	Id  string `json:"id"`
-	Key string `json:"key"`
+	Id        string `json:"id"`
+	Key       string `json:"key"`
+	Revoked   bool   `json:"revoked,omitempty"`
+	Expired   bool   `json:"expired,omitempty"`
+	Paused    bool   `json:"paused,omitempty"`
+	ExpireAt  int64  `json:"expire_at,omitempty"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+}
+
+// IsValid returns true if the key can be used to consume the subscribed API at the given time,
+// expressed in milliseconds since epoch as returned by APIM.
+func (key *ApiKeyEntity) IsValid(now int64) bool {
+	if key.Revoked || key.Expired || key.Paused {
+		return false
+	}
+	return key.ExpireAt == 0 || key.ExpireAt > now
 }
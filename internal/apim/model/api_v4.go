@@ -0,0 +1,44 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// ApiV4Status is the status returned by the v2 Management API when importing a v4 API definition.
+type ApiV4Status struct {
+	ID             string `json:"id"`
+	CrossID        string `json:"crossId"`
+	OrganizationID string `json:"organizationId"`
+	EnvironmentID  string `json:"environmentId"`
+	State          string `json:"state"`
+	// Plans holds the IDs of the API plans, indexed by plan name
+	Plans  map[string]string `json:"plans"`
+	Errors ImportErrors      `json:"errors"`
+}
+
+// ImportErrors holds the errors reported on import.
+// Severe errors prevent the API from being imported while warnings do not.
+type ImportErrors struct {
+	Severe  []string `json:"severe"`
+	Warning []string `json:"warning"`
+}
+
+func (errs ImportErrors) HasSevere() bool {
+	return len(errs.Severe) > 0
+}
+
+func (errs ImportErrors) All() []string {
+	all := make([]string, 0, len(errs.Severe)+len(errs.Warning))
+	all = append(all, errs.Severe...)
+	return append(all, errs.Warning...)
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+type Environment struct {
+	Id    string   `json:"id"`
+	Name  string   `json:"name,omitempty"`
+	Hrids []string `json:"hrids,omitempty"`
+}
+
+// Is returns true if the environment is identified either by the given ID or human readable ID.
+func (env *Environment) Is(id string) bool {
+	if env.Id == id {
+		return true
+	}
+	for _, hrid := range env.Hrids {
+		if hrid == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "strconv"
+
+const (
+	EventTypePublishApi = "PUBLISH_API"
+
+	deploymentNumberProperty = "deployment_number"
+	deploymentLabelProperty  = "deployment_label"
+)
+
+// Event is an event of the lifecycle of an API, such as one of its deployments.
+type Event struct {
+	Id         string            `json:"id"`
+	Type       string            `json:"type"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// DeploymentNumber returns the number of the deployment published by the event, or 0 if it is unknown.
+func (e *Event) DeploymentNumber() int {
+	number, err := strconv.Atoi(e.Properties[deploymentNumberProperty])
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// DeploymentLabel returns the label of the deployment published by the event.
+func (e *Event) DeploymentLabel() string {
+	return e.Properties[deploymentLabelProperty]
+}
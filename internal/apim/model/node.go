@@ -0,0 +1,29 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Node describes the instance of the management API serving the requests.
+type Node struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name,omitempty"`
+	Version NodeVersion `json:"version"`
+}
+
+type NodeVersion struct {
+	// Version is the full version of APIM (e.g. 4.2.0)
+	Version     string `json:"MAJOR_VERSION"`
+	Revision    string `json:"REVISION,omitempty"`
+	BuildNumber string `json:"BUILD_NUMBER,omitempty"`
+}
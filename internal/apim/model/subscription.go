@@ -14,6 +14,43 @@
 
 package model
 
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusPending  SubscriptionStatus = "PENDING"
+	SubscriptionStatusAccepted SubscriptionStatus = "ACCEPTED"
+	SubscriptionStatusPaused   SubscriptionStatus = "PAUSED"
+	SubscriptionStatusRejected SubscriptionStatus = "REJECTED"
+	SubscriptionStatusClosed   SubscriptionStatus = "CLOSED"
+)
+
 type Subscription struct {
-	Id string `json:"id"`
+	Id          string             `json:"id"`
+	Status      SubscriptionStatus `json:"status,omitempty"`
+	Plan        *SubscriptionRef   `json:"plan,omitempty"`
+	Application *SubscriptionRef   `json:"application,omitempty"`
+}
+
+// SubscriptionRef references the plan or application of a subscription.
+type SubscriptionRef struct {
+	Id   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func (sub *Subscription) IsClosed() bool {
+	return sub.Status == SubscriptionStatusClosed || sub.Status == SubscriptionStatusRejected
+}
+
+func (sub *Subscription) PlanID() string {
+	if sub.Plan == nil {
+		return ""
+	}
+	return sub.Plan.Id
+}
+
+func (sub *Subscription) ApplicationID() string {
+	if sub.Application == nil {
+		return ""
+	}
+	return sub.Application.Id
 }
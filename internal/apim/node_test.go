@@ -0,0 +1,74 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apim
+
+import (
+	"context"
+	netHttp "net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+var _ = Describe("Node", func() {
+	var server *httptest.Server
+	var found bool
+
+	BeforeEach(func() {
+		found = true
+		server = httptest.NewServer(netHttp.HandlerFunc(func(w netHttp.ResponseWriter, r *netHttp.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/management/_node"))
+			if !found {
+				w.WriteHeader(netHttp.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"node","name":"Gravitee.io - Management API",` +
+				`"version":{"BUILD_NUMBER":"1234","MAJOR_VERSION":"4.2.0","REVISION":"abcdef"}}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newAPIM := func() *APIM {
+		apim, err := FromContext(context.Background(), management.Context{
+			BaseUrl: server.URL,
+			OrgId:   "DEFAULT",
+			EnvId:   "DEFAULT",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return apim
+	}
+
+	It("Should return the version of the management API", func() {
+		node, err := newAPIM().Node.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Version.Version).To(Equal("4.2.0"))
+		Expect(node.Version.BuildNumber).To(Equal("1234"))
+	})
+
+	It("Should not find the node of a management API that does not expose it", func() {
+		found = false
+		_, err := newAPIM().Node.Get()
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+})
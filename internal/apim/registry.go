@@ -0,0 +1,143 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apim
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	netHttp "net/http"
+	"net/url"
+	"sync"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/http"
+)
+
+// Transports are cached by management context so that connections to APIM are kept alive
+// and reused across reconciles. The transport of a context is replaced as soon as the context,
+// or one of the secrets and config maps it references, has changed.
+// Limits are cached along with transports so that they apply to all the reconciles using a context.
+var transports = struct {
+	sync.Mutex
+	byContext map[string]transportEntry
+	limiters  map[string]*http.Limiter
+}{
+	byContext: make(map[string]transportEntry),
+	limiters:  make(map[string]*http.Limiter),
+}
+
+type transportEntry struct {
+	fingerprint string
+	transport   netHttp.RoundTripper
+	// The limits of the context followed by the limit of its APIM instance.
+	limits []http.Limit
+}
+
+// transportOf returns the transport of the management context, which must have been resolved.
+func transportOf(context *gio.ManagementContext, tlsMaterial *http.TLS) (transportEntry, error) {
+	fingerprint, err := fingerprintOf(context.Spec.Context, tlsMaterial)
+	if err != nil {
+		return transportEntry{}, err
+	}
+
+	transports.Lock()
+	defer transports.Unlock()
+
+	key := context.GetNamespacedName().String()
+	entry, ok := transports.byContext[key]
+	if ok && entry.fingerprint == fingerprint {
+		return entry, nil
+	}
+
+	tlsConfig, err := tlsMaterial.Config()
+	if err != nil {
+		return transportEntry{}, NewUnrecoverableError(err)
+	}
+
+	if ok {
+		closeIdleConnections(entry.transport)
+	}
+
+	entry = transportEntry{
+		fingerprint: fingerprint,
+		transport:   http.NewTransport(toHttpAuth(context.Spec.Context), tlsConfig),
+		limits:      append(limitsOf(context.Spec.RateLimit), limiterOf(context.Spec.BaseUrl)),
+	}
+	transports.byContext[key] = entry
+
+	return entry, nil
+}
+
+func limitsOf(rateLimit *management.RateLimit) []http.Limit {
+	if rateLimit == nil {
+		return []http.Limit{}
+	}
+
+	return []http.Limit{
+		http.NewRateLimiter(rateLimit.RequestsPerSecond, rateLimit.Burst),
+		http.NewLimiter(rateLimit.MaxInFlight),
+	}
+}
+
+// limiterOf returns the limiter shared by all the contexts targeting the same APIM instance.
+func limiterOf(baseUrl string) *http.Limiter {
+	host := baseUrl
+	if u, err := url.Parse(baseUrl); err == nil {
+		host = u.Host
+	}
+
+	if limiter, ok := transports.limiters[host]; ok {
+		return limiter
+	}
+
+	limiter := http.NewLimiter(env.Config.HttpClientConcurrency)
+	transports.limiters[host] = limiter
+	return limiter
+}
+
+// Invalidate removes the cached transport of a management context, closing its idle connections.
+func Invalidate(ref refs.NamespacedName) {
+	transports.Lock()
+	defer transports.Unlock()
+
+	if entry, ok := transports.byContext[ref.String()]; ok {
+		closeIdleConnections(entry.transport)
+		delete(transports.byContext, ref.String())
+	}
+}
+
+// fingerprintOf hashes the resolved context, including its credentials, and its TLS material.
+func fingerprintOf(context management.Context, tlsMaterial *http.TLS) (string, error) {
+	content, err := json.Marshal(struct {
+		Context management.Context
+		TLS     *http.TLS
+	}{context, tlsMaterial})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func closeIdleConnections(transport netHttp.RoundTripper) {
+	if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
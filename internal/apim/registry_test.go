@@ -0,0 +1,103 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apim
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/management"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/http"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newManagementContext(name, token string) *gio.ManagementContext {
+	return &gio.ManagementContext{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: gio.ManagementContextSpec{
+			Context: management.Context{
+				BaseUrl: "https://apim.example.com",
+				OrgId:   "DEFAULT",
+				EnvId:   "DEFAULT",
+				Auth:    &management.Auth{BearerToken: token},
+			},
+		},
+	}
+}
+
+var _ = Describe("Transport registry", func() {
+	It("Should reuse the transport of an unchanged context", func() {
+		first, err := transportOf(newManagementContext("reused", "token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		second, err := transportOf(newManagementContext("reused", "token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.transport).To(BeIdenticalTo(first.transport))
+	})
+
+	It("Should replace the transport when the credentials change", func() {
+		first, err := transportOf(newManagementContext("rotated", "token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		second, err := transportOf(newManagementContext("rotated", "rotated-token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.transport).ToNot(BeIdenticalTo(first.transport))
+	})
+
+	It("Should keep the current transport when the new TLS material is invalid", func() {
+		first, err := transportOf(newManagementContext("tls", "token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = transportOf(newManagementContext("tls", "token"), &http.TLS{CA: []byte("not a certificate")})
+		Expect(err).To(HaveOccurred())
+		Expect(IsRecoverable(err)).To(BeFalse())
+		second, err := transportOf(newManagementContext("tls", "token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.transport).To(BeIdenticalTo(first.transport))
+	})
+
+	It("Should not share transports between contexts", func() {
+		first, err := transportOf(newManagementContext("dev", "token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		second, err := transportOf(newManagementContext("staging", "token"), new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.transport).ToNot(BeIdenticalTo(first.transport))
+	})
+
+	It("Should create a new transport once the context has been invalidated", func() {
+		context := newManagementContext("deleted", "token")
+		first, err := transportOf(context, new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		Invalidate(*context.GetNamespacedName())
+		second, err := transportOf(context, new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second.transport).ToNot(BeIdenticalTo(first.transport))
+	})
+
+	It("Should limit the requests of a context with a rate limit", func() {
+		context := newManagementContext("limited", "token")
+		context.Spec.RateLimit = &management.RateLimit{RequestsPerSecond: 5, MaxInFlight: 2}
+		entry, err := transportOf(context, new(http.TLS))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entry.limits).To(HaveLen(3))
+		Expect(entry.limits[0]).To(BeAssignableToTypeOf(&http.RateLimiter{}))
+		Expect(entry.limits[0]).ToNot(BeNil())
+	})
+
+	It("Should share the concurrency limit of an APIM instance between contexts", func() {
+		transports.Lock()
+		defer transports.Unlock()
+		Expect(limiterOf("https://apim.example.com/management")).
+			To(BeIdenticalTo(limiterOf("https://apim.example.com")))
+	})
+})
@@ -29,12 +29,19 @@ const (
 	stateActionParam = "action"
 	planParam        = "plan"
 	applicationParam = "application"
+	statusParam      = "status"
+	typeParam        = "type"
 )
 
 var importParams = map[string]string{
 	"definitionVersion": "2.0.0",
 }
 
+var exportParams = map[string]string{
+	"version": "2.0.0",
+	"exclude": "groups,members,pages",
+}
+
 var deleteParams = map[string]string{
 	"closePlans": "true",
 }
@@ -65,6 +72,30 @@ func (svc *APIs) GetByCrossID(crossID string) (*model.ApiListItem, error) {
 	return &(*apis)[0], nil
 }
 
+// List returns all the APIs of the environment.
+func (svc *APIs) List() ([]model.ApiListItem, error) {
+	url := svc.EnvTarget("apis")
+	apis := new([]model.ApiListItem)
+
+	if err := svc.HTTP.Get(url.String(), apis); err != nil {
+		return nil, err
+	}
+
+	return *apis, nil
+}
+
+// Export returns the definition of an API, as expected by the import endpoint.
+func (svc *APIs) Export(apiID string) (*v2.Api, error) {
+	url := svc.EnvTarget("apis").WithPath(apiID).WithPath("export").WithQueryParams(exportParams)
+	api := new(v2.Api)
+
+	if err := svc.HTTP.Get(url.String(), api); err != nil {
+		return nil, err
+	}
+
+	return api, nil
+}
+
 func (svc *APIs) GetByID(apiID string) (*model.ApiEntity, error) {
 	url := svc.EnvTarget("apis").WithPath(apiID)
 	api := new(model.ApiEntity)
@@ -110,7 +141,36 @@ func (svc *APIs) SetKubernetesContext(apiID string) error {
 	return svc.HTTP.Put(url.String(), model.NewKubernetesContext(), nil)
 }
 
-func (svc *APIs) Deploy(id string) error {
+func (svc *APIs) SetManagementContext(apiID string) error {
+	url := svc.EnvTarget("apis").WithPath(apiID).WithPath("definition-context")
+	return svc.HTTP.Put(url.String(), model.NewManagementContext(), nil)
+}
+
+// Deploy deploys the current definition of the API to the gateways, the label describing the deployment.
+func (svc *APIs) Deploy(id, label string) error {
 	url := svc.EnvTarget("apis").WithPath(id).WithPath("deploy")
-	return svc.HTTP.Post(url.String(), new(model.ApiDeployment), nil)
+	return svc.HTTP.Post(url.String(), &model.ApiDeployment{DeploymentLabel: label}, nil)
+}
+
+// LastDeployment returns the event published by the last deployment of the API.
+func (svc *APIs) LastDeployment(id string) (*model.Event, error) {
+	url := svc.EnvTarget("apis").WithPath(id).WithPath("events").WithQueryParam(typeParam, model.EventTypePublishApi)
+	events := new([]model.Event)
+
+	if err := svc.HTTP.Get(url.String(), events); err != nil {
+		return nil, err
+	}
+
+	var last *model.Event
+	for i := range *events {
+		if last == nil || (*events)[i].DeploymentNumber() > last.DeploymentNumber() {
+			last = &(*events)[i]
+		}
+	}
+
+	if last == nil {
+		return nil, errors.NewNotFoundError()
+	}
+
+	return last, nil
 }
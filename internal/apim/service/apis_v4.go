@@ -0,0 +1,58 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	v4 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v4"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/client"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+)
+
+// APIsV4 brings support for managing gravitee.io APIM v4 APIs, using the v2 Management API.
+type APIsV4 struct {
+	*client.Client
+}
+
+func NewAPIsV4(client *client.Client) *APIsV4 {
+	return &APIsV4{Client: client}
+}
+
+func (svc *APIsV4) GetByID(apiID string) (*v4.Api, error) {
+	url := svc.EnvV2Target("apis").WithPath(apiID)
+	api := new(v4.Api)
+
+	if err := svc.HTTP.Get(url.String(), api); err != nil {
+		return nil, err
+	}
+
+	return api, nil
+}
+
+// ImportCRD creates or updates the given v4 API, matching existing APIs on their cross ID.
+func (svc *APIsV4) ImportCRD(spec *v4.Api) (*model.ApiV4Status, error) {
+	url := svc.EnvV2Target("apis/_import/crd")
+	status := new(model.ApiV4Status)
+
+	if err := svc.HTTP.Put(url.String(), spec, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (svc *APIsV4) Delete(apiID string) error {
+	url := svc.EnvV2Target("apis").WithPath(apiID).WithQueryParams(deleteParams)
+	return svc.HTTP.Delete(url.String(), nil)
+}
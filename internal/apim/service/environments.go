@@ -0,0 +1,51 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/client"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+const environmentsPath = "/environments"
+
+// Environments brings support for reading the environments of a gravitee.io APIM organization.
+type Environments struct {
+	*client.Client
+}
+
+func NewEnvironments(client *client.Client) *Environments {
+	return &Environments{Client: client}
+}
+
+// GetByID returns the environment of the organization identified by the given ID or human readable ID.
+// This is an authenticated call cheap enough to be used to check that APIM is reachable.
+func (svc *Environments) GetByID(envID string) (*model.Environment, error) {
+	url := svc.OrgTarget(environmentsPath)
+	environments := new([]model.Environment)
+
+	if err := svc.HTTP.Get(url.String(), environments); err != nil {
+		return nil, err
+	}
+
+	for i := range *environments {
+		if env := &(*environments)[i]; env.Is(envID) {
+			return env, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError()
+}
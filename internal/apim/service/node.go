@@ -0,0 +1,43 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/client"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+)
+
+const nodePath = "/_node"
+
+// Node brings support for reading information about the gravitee.io APIM management API.
+type Node struct {
+	*client.Client
+}
+
+func NewNode(client *client.Client) *Node {
+	return &Node{Client: client}
+}
+
+// Get returns the information of the management API node serving the request, including its version.
+func (svc *Node) Get() (*model.Node, error) {
+	url := svc.BaseTarget(nodePath)
+	node := new(model.Node)
+
+	if err := svc.HTTP.Get(url.String(), node); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
@@ -21,7 +21,6 @@ import (
 )
 
 // Subscriptions brings support for managing gravitee.io APIM support for subscriptions.
-// This service is used for testing purposes only and not initialized by the operator manager.
 type Subscriptions struct {
 	*client.Client
 }
@@ -51,6 +50,24 @@ func (svc *Subscriptions) Subscribe(apiID, applicationID, planID string) (*model
 	return subscription, nil
 }
 
+func (svc *Subscriptions) GetByID(apiID, subscriptionID string) (*model.Subscription, error) {
+	url := svc.APITarget(apiID).WithPath(subscriptionID)
+	subscription := new(model.Subscription)
+
+	if err := svc.HTTP.Get(url.String(), subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (svc *Subscriptions) Close(apiID, subscriptionID string) error {
+	url := svc.APITarget(apiID).WithPath(subscriptionID).WithPath("status").WithQueryParam(
+		statusParam, string(model.SubscriptionStatusClosed),
+	)
+	return svc.HTTP.Post(url.String(), nil, nil)
+}
+
 func (svc *Subscriptions) GetApiKeys(apiID, subscriptionID string) ([]model.ApiKeyEntity, error) {
 	url := svc.APITarget(apiID).WithPath(subscriptionID).WithPath("apikeys")
 	apiKeys := new([]model.ApiKeyEntity)
@@ -0,0 +1,210 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conditions computes the standard status conditions reported on every custom resource
+// handled by the operator, so that tools like kubectl wait, Argo CD or Flux can check their health.
+package conditions
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	apimErrors "github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	kErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types.
+const (
+	// Ready summarizes the other conditions and is true once the resource has been fully reconciled.
+	Ready = "Ready"
+	// Accepted is false when the resource has been rejected, either by the operator or by APIM.
+	Accepted = "Accepted"
+	// ResolvedRefs is false when a resource referenced by the resource can not be found.
+	ResolvedRefs = "ResolvedRefs"
+	// ContextReachable is false when the APIM instance of the management context can not be reached.
+	ContextReachable = "ContextReachable"
+	// Synced is false when the resource could not be synced with APIM.
+	Synced = "Synced"
+	// Deployed is false when an API definition could not be deployed to the gateway.
+	Deployed = "Deployed"
+	// Paused is true when the reconciliation of the resource has been paused.
+	Paused = "Paused"
+)
+
+// Condition reasons.
+const (
+	ReasonReconciled      = "Reconciled"
+	ReasonAccepted        = "Accepted"
+	ReasonRejected        = "Rejected"
+	ReasonResolved        = "ResolvedRefs"
+	ReasonRefNotFound     = "RefNotFound"
+	ReasonReachable       = "Reachable"
+	ReasonUnreachable     = "Unreachable"
+	ReasonUnauthorized    = "Unauthorized"
+	ReasonCircuitOpen     = "CircuitOpen"
+	ReasonUnknownEnv      = "UnknownEnvironment"
+	ReasonSynced          = "Synced"
+	ReasonSyncFailed      = "SyncFailed"
+	ReasonDrifted         = "Drifted"
+	ReasonDryRun          = "DryRun"
+	ReasonPaused          = "Paused"
+	ReasonDeployed        = "Deployed"
+	ReasonDeployFailed    = "DeployFailed"
+	ReasonTooLarge        = "DefinitionTooLarge"
+	ReasonReconcileFailed = "ReconcileFailed"
+)
+
+var successReasons = map[string]string{
+	Ready:            ReasonReconciled,
+	Accepted:         ReasonAccepted,
+	ResolvedRefs:     ReasonResolved,
+	ContextReachable: ReasonReachable,
+	Synced:           ReasonSynced,
+	Deployed:         ReasonDeployed,
+}
+
+// Error can be used to report a reconcile error on a given condition type
+// when it can not be inferred from the error itself.
+type Error struct {
+	Type   string
+	Reason string
+	error
+}
+
+func (e Error) Unwrap() error {
+	return e.error
+}
+
+// NewError wraps err so that it is reported on the given condition type with the given reason.
+func NewError(conditionType, reason string, err error) error {
+	return Error{Type: conditionType, Reason: reason, error: err}
+}
+
+// NewDeployError wraps err so that it is reported on the Deployed condition.
+func NewDeployError(err error) error {
+	return NewError(Deployed, ReasonDeployFailed, err)
+}
+
+// SetSucceeded marks the given condition types and the Ready condition as true.
+// Other condition types reported on a reconcile, e.g. left failed by a previous reconcile,
+// are removed as they do not apply to the resource anymore. The Paused condition is left untouched.
+func SetSucceeded(conditions *[]metav1.Condition, generation int64, types ...string) {
+	types = append(types, Ready)
+	for conditionType := range successReasons {
+		if !slices.Contains(types, conditionType) {
+			meta.RemoveStatusCondition(conditions, conditionType)
+		}
+	}
+	setTrue(conditions, generation, types...)
+}
+
+// SetAccepted marks the Accepted condition as true, leaving other conditions untouched,
+// e.g. when a resource is validated without being applied.
+func SetAccepted(conditions *[]metav1.Condition, generation int64) {
+	setTrue(conditions, generation, Accepted)
+}
+
+func setTrue(conditions *[]metav1.Condition, generation int64, types ...string) {
+	for _, conditionType := range types {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             successReasons[conditionType],
+			Message:            "",
+			ObservedGeneration: generation,
+		})
+	}
+}
+
+// SetFailed marks the condition type the error relates to and the Ready condition as false,
+// using the error to set the reason and message of the conditions.
+// Other conditions are left untouched.
+func SetFailed(conditions *[]metav1.Condition, generation int64, err error) {
+	conditionType, reason := FromError(err)
+	for _, t := range []string{conditionType, Ready} {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               t,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            err.Error(),
+			ObservedGeneration: generation,
+		})
+	}
+}
+
+// SetPaused marks the Paused condition as true, the message describing why the reconciliation is paused.
+// Other conditions are left untouched, reporting the state of the resource before it was paused.
+func SetPaused(conditions *[]metav1.Condition, generation int64, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               Paused,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonPaused,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
+
+// ClearPaused removes the Paused condition once the reconciliation has been resumed.
+func ClearPaused(conditions *[]metav1.Condition) {
+	meta.RemoveStatusCondition(conditions, Paused)
+}
+
+// FromError returns the condition type and reason a reconcile error should be reported on.
+func FromError(err error) (string, string) {
+	conditionError := &Error{}
+	if errors.As(err, conditionError) {
+		return conditionError.Type, conditionError.Reason
+	}
+
+	if errors.As(err, new(apim.UnrecoverableError)) {
+		return Accepted, ReasonRejected
+	}
+
+	if kErrors.IsNotFound(err) {
+		return ResolvedRefs, ReasonRefNotFound
+	}
+
+	if _, open := apimErrors.IsCircuitOpen(err); open {
+		return ContextReachable, ReasonCircuitOpen
+	}
+
+	if errors.As(err, new(*url.Error)) {
+		return ContextReachable, ReasonUnreachable
+	}
+
+	serverError := &apimErrors.ServerError{}
+	if errors.As(err, serverError) {
+		return fromServerError(serverError)
+	}
+
+	return Ready, ReasonReconcileFailed
+}
+
+func fromServerError(err *apimErrors.ServerError) (string, string) {
+	switch {
+	case err.StatusCode == http.StatusBadRequest:
+		return Accepted, ReasonRejected
+	case err.StatusCode == http.StatusUnauthorized || err.StatusCode == http.StatusForbidden:
+		return ContextReachable, ReasonUnauthorized
+	case err.StatusCode >= http.StatusInternalServerError:
+		return ContextReachable, ReasonUnreachable
+	default:
+		return Synced, ReasonSyncFailed
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conditions
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	apimErrors "github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	kErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var errRaw = fmt.Errorf("raw error")
+
+var _ = Describe("Conditions", func() {
+	DescribeTable("condition type and reason from error",
+		func(given error, expectedType, expectedReason string) {
+			conditionType, reason := FromError(given)
+			Expect(conditionType).To(Equal(expectedType))
+			Expect(reason).To(Equal(expectedReason))
+		},
+		Entry("With raw error", errRaw, Ready, ReasonReconcileFailed),
+		Entry("With condition error", NewDeployError(errRaw), Deployed, ReasonDeployFailed),
+		Entry("With unrecoverable error", apim.NewUnrecoverableError(errRaw), Accepted, ReasonRejected),
+		Entry("With missing reference",
+			kErrors.NewNotFound(schema.GroupResource{Resource: "managementcontexts"}, "dev-ctx"),
+			ResolvedRefs, ReasonRefNotFound),
+		Entry("With unreachable context",
+			apim.NewContextError(fmt.Errorf("wrapped: %w", &url.Error{Op: "Get", URL: "http://apim", Err: errRaw})),
+			ContextReachable, ReasonUnreachable),
+		Entry("With open circuit breaker",
+			apim.NewContextError(apimErrors.CircuitOpenError{Host: "apim", RetryAfter: time.Minute}),
+			ContextReachable, ReasonCircuitOpen),
+		Entry("With unauthorized error",
+			apim.NewContextError(apimErrors.ServerError{StatusCode: 401}), ContextReachable, ReasonUnauthorized),
+		Entry("With bad request", apim.NewContextError(apimErrors.ServerError{StatusCode: 400}), Accepted, ReasonRejected),
+		Entry("With server error",
+			apim.NewContextError(apimErrors.ServerError{StatusCode: 503}), ContextReachable, ReasonUnreachable),
+		Entry("With not found error",
+			apim.NewContextError(apimErrors.ServerError{StatusCode: 404}), Synced, ReasonSyncFailed),
+	)
+
+	It("Should mark conditions as failed and then as succeeded", func() {
+		conditions := make([]metav1.Condition, 0)
+
+		SetFailed(&conditions, 1, NewDeployError(errRaw))
+		Expect(meta.IsStatusConditionFalse(conditions, Ready)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(conditions, Deployed)).To(BeTrue())
+		Expect(meta.FindStatusCondition(conditions, Ready).Message).To(Equal(errRaw.Error()))
+
+		SetSucceeded(&conditions, 2, Deployed)
+		Expect(meta.IsStatusConditionTrue(conditions, Ready)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, Deployed)).To(BeTrue())
+		Expect(meta.FindStatusCondition(conditions, Ready).ObservedGeneration).To(Equal(int64(2)))
+	})
+
+	It("Should remove conditions that are not reported anymore once succeeded", func() {
+		conditions := make([]metav1.Condition, 0)
+
+		SetFailed(&conditions, 1, NewError(Synced, ReasonSyncFailed, errRaw))
+		SetFailed(&conditions, 1, NewDeployError(errRaw))
+		SetPaused(&conditions, 1, "namespace default is paused")
+
+		SetSucceeded(&conditions, 2, Accepted, Deployed)
+		Expect(meta.FindStatusCondition(conditions, Synced)).To(BeNil())
+		Expect(meta.IsStatusConditionTrue(conditions, Deployed)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, Paused)).To(BeTrue())
+		for _, condition := range conditions {
+			Expect(condition.Reason).ToNot(BeElementOf(ReasonSyncFailed, ReasonDeployFailed, ReasonReconcileFailed))
+		}
+	})
+
+	It("Should only mark the Accepted condition when accepting", func() {
+		conditions := make([]metav1.Condition, 0)
+		SetSucceeded(&conditions, 1, Accepted, Deployed)
+
+		SetAccepted(&conditions, 2)
+		Expect(meta.FindStatusCondition(conditions, Accepted).ObservedGeneration).To(Equal(int64(2)))
+		Expect(meta.FindStatusCondition(conditions, Deployed).ObservedGeneration).To(Equal(int64(1)))
+	})
+
+	It("Should set and clear the Paused condition", func() {
+		conditions := make([]metav1.Condition, 0)
+		SetSucceeded(&conditions, 1, Synced)
+
+		SetPaused(&conditions, 1, "namespace default is paused")
+		Expect(meta.IsStatusConditionTrue(conditions, Paused)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, Ready)).To(BeTrue())
+
+		ClearPaused(&conditions)
+		Expect(meta.FindStatusCondition(conditions, Paused)).To(BeNil())
+		Expect(meta.IsStatusConditionTrue(conditions, Synced)).To(BeTrue())
+	})
+})
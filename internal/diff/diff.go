@@ -0,0 +1,213 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff computes the semantic differences between the desired state of a resource
+// and the state of that resource as found in APIM.
+package diff
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Redacted is reported in place of the values that may have been read from secrets.
+const Redacted = "<redacted>"
+
+// The left delimiter of the templates of resources.
+const templateDelim = "[["
+
+// Compare returns a human readable description of every difference found between desired and actual,
+// sorted by path (e.g. `name: expected "foo", found "bar"`).
+//
+// Both values are compared on their JSON representation, so that only the values set in the desired state
+// are taken into account. Fields that are only known by APIM (e.g. IDs or timestamps) are ignored.
+func Compare(desired, actual any) ([]string, error) {
+	return CompareRedacted(desired, actual, nil)
+}
+
+// CompareRedacted is like Compare, templated being the desired state before its templates are resolved.
+// The values of the fields holding a template in templated are redacted, both expected and found,
+// as they may have been read from secrets (e.g. `password: expected "<redacted>", found "<redacted>"`).
+func CompareRedacted(desired, actual, templated any) ([]string, error) {
+	desiredValue, err := normalize(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	actualValue, err := normalize(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	templatedValue, err := normalize(templated)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]string, 0)
+	compare("", desiredValue, actualValue, templatedValue, false, &changes)
+	sort.Strings(changes)
+
+	return changes, nil
+}
+
+// Hash returns a hash of the JSON representation of value,
+// which can be compared with a previous hash to tell whether value has changed.
+func Hash(value any) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func normalize(value any) (any, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	if err = json.Unmarshal(b, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+// Values below a templated field are redacted, the template being resolved
+// into a single value that may be structured (e.g. with toJSON).
+func compare(path string, desired, actual, templated any, redacted bool, changes *[]string) {
+	redacted = redacted || isTemplate(templated)
+
+	switch d := desired.(type) {
+	case nil:
+		return
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			*changes = append(*changes, change(path, desired, actual, redacted || containsTemplate(templated)))
+			return
+		}
+		t, _ := templated.(map[string]any)
+		for key, value := range d {
+			compare(join(path, key), value, a[key], t[key], redacted, changes)
+		}
+	case []any:
+		a, ok := actual.([]any)
+		if !ok || len(a) != len(d) {
+			*changes = append(*changes, change(path, desired, actual, redacted || containsTemplate(templated)))
+			return
+		}
+		t, _ := templated.([]any)
+		for i := range d {
+			compare(fmt.Sprintf("%s[%d]", path, i), d[i], a[i], elem(t, i), redacted, changes)
+		}
+	default:
+		if !reflect.DeepEqual(desired, actual) {
+			*changes = append(*changes, change(path, desired, actual, redacted))
+		}
+	}
+}
+
+func change(path string, desired, actual any, redacted bool) string {
+	if redacted {
+		desired, actual = redact(desired), redact(actual)
+	}
+	if actual == nil {
+		return fmt.Sprintf("%s: expected %s, found none", path, format(desired))
+	}
+	return fmt.Sprintf("%s: expected %s, found %s", path, format(desired), format(actual))
+}
+
+// redact replaces every value of a JSON value with Redacted, keeping its structure.
+func redact(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, item := range v {
+			redacted[key] = redact(item)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redact(item)
+		}
+		return redacted
+	default:
+		return Redacted
+	}
+}
+
+func isTemplate(value any) bool {
+	s, ok := value.(string)
+	return ok && strings.Contains(s, templateDelim)
+}
+
+// containsTemplate returns true if value or any value below it is a template.
+func containsTemplate(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, item := range v {
+			if containsTemplate(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if containsTemplate(item) {
+				return true
+			}
+		}
+	default:
+		return isTemplate(value)
+	}
+	return false
+}
+
+func elem(values []any, i int) any {
+	if i < len(values) {
+		return values[i]
+	}
+	return nil
+}
+
+// Values are not HTML escaped, to be read as they are in the resource.
+func format(value any) string {
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return strings.Join([]string{path, key}, ".")
+}
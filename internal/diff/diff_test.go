@@ -0,0 +1,111 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type plan struct {
+	Name     string `json:"name"`
+	Security string `json:"security,omitempty"`
+}
+
+type api struct {
+	Name  string   `json:"name,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+	Plans []plan   `json:"plans,omitempty"`
+}
+
+type apiWithID struct {
+	api `json:",inline"`
+	ID  string `json:"id"`
+}
+
+var _ = Describe("Compare", func() {
+	DescribeTable("differences between desired and actual state",
+		func(desired, actual any, expected []string) {
+			changes, err := Compare(desired, actual)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(Equal(expected))
+		},
+		Entry("With same state",
+			api{Name: "api", Tags: []string{"a"}},
+			api{Name: "api", Tags: []string{"a"}},
+			[]string{},
+		),
+		Entry("With a field only known by the actual state",
+			api{Name: "api"},
+			apiWithID{api: api{Name: "api"}, ID: "id"},
+			[]string{},
+		),
+		Entry("With a changed field",
+			api{Name: "api"},
+			api{Name: "renamed"},
+			[]string{`name: expected "api", found "renamed"`},
+		),
+		Entry("With a missing field",
+			api{Name: "api", Tags: []string{"a"}},
+			api{Name: "api"},
+			[]string{`tags: expected ["a"], found none`},
+		),
+		Entry("With a changed list element",
+			api{Plans: []plan{{Name: "free", Security: "KEY_LESS"}}},
+			api{Plans: []plan{{Name: "free", Security: "API_KEY"}}},
+			[]string{`plans[0].security: expected "KEY_LESS", found "API_KEY"`},
+		),
+		Entry("With a list of different length",
+			api{Tags: []string{"a"}},
+			api{Tags: []string{"a", "b"}},
+			[]string{`tags: expected ["a"], found ["a","b"]`},
+		),
+	)
+
+	DescribeTable("differences with templated values",
+		func(desired, actual, templated any, expected []string) {
+			changes, err := CompareRedacted(desired, actual, templated)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(Equal(expected))
+		},
+		Entry("With a changed templated field",
+			api{Name: "s3cr3t"},
+			api{Name: "previous"},
+			api{Name: `[[ secret "api/name" ]]`},
+			[]string{`name: expected "<redacted>", found "<redacted>"`},
+		),
+		Entry("With a templated field that did not change",
+			api{Name: "s3cr3t"},
+			api{Name: "s3cr3t"},
+			api{Name: `[[ secret "api/name" ]]`},
+			[]string{},
+		),
+		Entry("With a list holding a templated element",
+			api{Tags: []string{"a", "s3cr3t"}},
+			api{Tags: []string{"a"}},
+			api{Tags: []string{"a", `[[ secret "api/tag" ]]`}},
+			[]string{`tags: expected ["<redacted>","<redacted>"], found ["<redacted>"]`},
+		),
+		Entry("With a field that is not templated",
+			api{Name: "api", Plans: []plan{{Name: "free", Security: "s3cr3t"}}},
+			api{Name: "renamed", Plans: []plan{{Name: "free", Security: "previous"}}},
+			api{Name: "api", Plans: []plan{{Name: "free", Security: `[[ secret "api/security" ]]`}}},
+			[]string{
+				`name: expected "api", found "renamed"`,
+				`plans[0].security: expected "<redacted>", found "<redacted>"`,
+			},
+		),
+	)
+})
@@ -16,6 +16,10 @@ package env
 
 import (
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 )
 
 const (
@@ -25,18 +29,91 @@ const (
 	NS                     = "NAMESPACE"
 	ApplyCRDs              = "APPLY_CRDS"
 	EnableMetrics          = "ENABLE_METRICS"
+	EnableWebhook          = "ENABLE_WEBHOOK"
+	DeletionPolicy         = "DELETION_POLICY"
 	InsecureSkipCertVerify = "INSECURE_SKIP_CERT_VERIFY"
+	HttpClientTimeout      = "HTTP_CLIENT_TIMEOUT_SECONDS"
+	HttpClientMaxRetries   = "HTTP_CLIENT_MAX_RETRIES"
+	HttpClientConcurrency  = "HTTP_CLIENT_MAX_CONCURRENCY"
+	BreakerThreshold       = "CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+	BreakerCooldown        = "CIRCUIT_BREAKER_COOLDOWN_SECONDS"
+	ContextProbeInterval   = "CONTEXT_PROBE_INTERVAL_SECONDS"
+	ContextReadinessCheck  = "ENABLE_CONTEXT_READINESS_CHECK"
+	TracingEndpoint        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	TracingServiceName     = "OTEL_SERVICE_NAME"
+	DeploymentLabelKeys    = "DEPLOYMENT_LABEL_ANNOTATIONS"
+	DeploymentHistoryLimit = "DEPLOYMENT_HISTORY_LIMIT"
+	LocalDefinitionStore   = "LOCAL_DEFINITION_STORE"
+	MigrateDefinitions     = "MIGRATE_LOCAL_DEFINITIONS"
+	TemplateEnvVars        = "TEMPLATE_ENV_VARS"
+	SecretProviderHosts    = "SECRET_PROVIDER_ALLOWED_HOSTS"
+	VaultTokenPath         = "SECRET_PROVIDER_VAULT_TOKEN_PATH"
+	HTTPTokenPath          = "SECRET_PROVIDER_HTTP_TOKEN_PATH"
 	trueString             = "true"
+	defaultDeletionPolicy  = "Delete"
+	defaultTimeout         = 5
+	defaultMaxRetries      = 3
+	defaultConcurrency     = 10
+	defaultThreshold       = 5
+	defaultCooldown        = 30
+	defaultProbeInterval   = 60
+	defaultServiceName     = "gko"
+	defaultLabelKeys       = keys.DeploymentLabelAnnotation
+	defaultHistoryLimit    = 10
+	defaultDefinitionStore = ConfigMapStore
+	defaultVaultTokenPath  = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// Kinds of objects the definitions of local APIs can be written to.
+const (
+	ConfigMapStore = "ConfigMap"
+	SecretStore    = "Secret"
 )
 
 var Config = struct {
 	NS                 string
 	ApplyCRDs          bool
 	EnableMetrics      bool
+	EnableWebhook      bool
+	DeletionPolicy     string
 	Development        bool
 	CMTemplate404Name  string
 	CMTemplate404NS    string
 	InsecureSkipVerify bool
+	// Timeout of a single request to APIM, in seconds.
+	HttpClientTimeout int
+	// Number of times a failed request to APIM is retried.
+	HttpClientMaxRetries int
+	// Maximum number of concurrent requests to an APIM instance, unbounded if zero.
+	HttpClientConcurrency int
+	// Number of consecutive failures after which requests to a management context are stopped.
+	BreakerThreshold int
+	// Time during which requests to a management context are stopped, in seconds.
+	BreakerCooldown int
+	// Interval at which the management API of each context is probed, in seconds, never if zero.
+	ContextProbeInterval int
+	// Whether the operator is ready only when all management contexts are reachable.
+	ContextReadinessCheck bool
+	// Base URL of the OTLP/HTTP collector receiving traces, tracing is disabled if empty.
+	TracingEndpoint string
+	// Name of the service reported in traces.
+	TracingServiceName string
+	// Annotations looked up in order to label the deployments of an API, the generation being used otherwise.
+	DeploymentLabelKeys []string
+	// Number of deployments kept in the status of an API.
+	DeploymentHistoryLimit int
+	// Kind of object the definitions of local APIs are written to, either ConfigMap or Secret.
+	LocalDefinitionStore string
+	// Whether the config maps of local APIs are deleted once their definition is written to a secret.
+	MigrateLocalDefinitions bool
+	// Environment variables of the operator that can be read in templates with the env function.
+	TemplateEnvVars []string
+	// Hosts secret providers can read values from, either a host name or a host and port.
+	SecretProviderHosts []string
+	// Path of the token the operator logs in to Vault with.
+	VaultTokenPath string
+	// Path of the bearer token sent to HTTP secret providers, no token is sent if empty.
+	HTTPTokenPath string
 }{}
 
 func init() {
@@ -47,4 +124,48 @@ func init() {
 	Config.CMTemplate404NS = os.Getenv(CMTemplate404NS)
 	Config.InsecureSkipVerify = os.Getenv(InsecureSkipCertVerify) == trueString
 	Config.EnableMetrics = os.Getenv(EnableMetrics) == trueString
+	Config.EnableWebhook = os.Getenv(EnableWebhook) == trueString
+	Config.DeletionPolicy = getOrDefault(DeletionPolicy, defaultDeletionPolicy)
+	Config.HttpClientTimeout = getIntOrDefault(HttpClientTimeout, defaultTimeout)
+	Config.HttpClientMaxRetries = getIntOrDefault(HttpClientMaxRetries, defaultMaxRetries)
+	Config.HttpClientConcurrency = getIntOrDefault(HttpClientConcurrency, defaultConcurrency)
+	Config.BreakerThreshold = getIntOrDefault(BreakerThreshold, defaultThreshold)
+	Config.BreakerCooldown = getIntOrDefault(BreakerCooldown, defaultCooldown)
+	Config.ContextProbeInterval = getIntOrDefault(ContextProbeInterval, defaultProbeInterval)
+	Config.ContextReadinessCheck = os.Getenv(ContextReadinessCheck) == trueString
+	Config.TracingEndpoint = os.Getenv(TracingEndpoint)
+	Config.TracingServiceName = getOrDefault(TracingServiceName, defaultServiceName)
+	Config.DeploymentLabelKeys = getListOrDefault(DeploymentLabelKeys, defaultLabelKeys)
+	Config.DeploymentHistoryLimit = getIntOrDefault(DeploymentHistoryLimit, defaultHistoryLimit)
+	Config.LocalDefinitionStore = getOrDefault(LocalDefinitionStore, defaultDefinitionStore)
+	Config.MigrateLocalDefinitions = os.Getenv(MigrateDefinitions) == trueString
+	Config.TemplateEnvVars = getListOrDefault(TemplateEnvVars, "")
+	Config.SecretProviderHosts = getListOrDefault(SecretProviderHosts, "")
+	Config.VaultTokenPath = getOrDefault(VaultTokenPath, defaultVaultTokenPath)
+	Config.HTTPTokenPath = os.Getenv(HTTPTokenPath)
+}
+
+func getOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getIntOrDefault(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func getListOrDefault(key, defaultValue string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(getOrDefault(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
 }
@@ -0,0 +1,119 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+)
+
+type lookupFunc = func(string) (string, error)
+
+// providerFunc looks up the value of a key at a path in the backend of a secret provider.
+type providerFunc = func(name, path, key string) (string, error)
+
+// funcMap returns the functions available in templates,
+// config maps, secrets and secret providers being looked up with the given functions.
+func funcMap(configMap, secret lookupFunc, provider providerFunc) template.FuncMap {
+	return template.FuncMap{
+		configMapKind: configMap,
+		secretKind:    secret,
+		providerKind:  provider,
+		"default":     defaultValue,
+		"required":    required,
+		"b64enc":      b64enc,
+		"b64dec":      b64dec,
+		"json":        toJSON,
+		"env":         lookupEnv,
+		"indent":      indent,
+		"nindent":     nindent,
+	}
+}
+
+// staticFuncMap returns the functions used when templates are executed without being resolved,
+// functions depending on the values they are given do not fail as these values are never read.
+func staticFuncMap(configMap, secret lookupFunc, provider providerFunc) template.FuncMap {
+	funcs := funcMap(configMap, secret, provider)
+	funcs["required"] = func(_, value string) string { return value }
+	funcs["b64dec"] = func(string) string { return "" }
+	return funcs
+}
+
+// defaultValue returns value, or def if value is empty
+// (e.g. [[ secret "my-secret/key1" | default "value" ]]).
+func defaultValue(def, value string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+// required fails with the given message if value is empty
+// (e.g. [[ secret "my-secret/key1" | required "key1 must be set" ]]).
+func required(message, value string) (string, error) {
+	if value == "" {
+		return "", errors.New(message)
+	}
+	return value, nil
+}
+
+func b64enc(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+func b64dec(value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode base64 value: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// toJSON encodes value as a JSON string, quotes and line breaks included.
+func toJSON(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// lookupEnv reads an environment variable of the operator.
+// Only the variables listed in the configuration of the operator can be read.
+func lookupEnv(name string) (string, error) {
+	if !slices.Contains(env.Config.TemplateEnvVars, name) {
+		return "", fmt.Errorf("environment variable %s is not exposed to templates", name)
+	}
+	return os.Getenv(name), nil
+}
+
+// indent indents each line of value with the given number of spaces, e.g. to embed multi-line values.
+func indent(spaces int, value string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(value, "\n", "\n"+pad)
+}
+
+// nindent is like indent, starting with a new line.
+func nindent(spaces int, value string) string {
+	return "\n" + indent(spaces, value)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"io"
+	"text/template"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	configMapKind = "configmap"
+	secretKind    = "secret"
+	providerKind  = "provider"
+)
+
+// ConfigMapRefs returns the config maps used in the template expressions of the given object
+// (e.g. [[ configmap "my-configmap/key1" ]]), without resolving them.
+func ConfigMapRefs(obj runtime.Object) []refs.NamespacedName {
+	return findRefs(obj, configMapKind)
+}
+
+// SecretRefs returns the secrets used in the template expressions of the given object
+// (e.g. [[ secret "my-secret/key1" ]]), without resolving them.
+func SecretRefs(obj runtime.Object) []refs.NamespacedName {
+	return findRefs(obj, secretKind)
+}
+
+// ProviderRefs returns the secret providers used in the template expressions of the given object
+// (e.g. [[ provider "my-provider" "path/to/secret" "key1" ]]), without resolving them.
+func ProviderRefs(obj runtime.Object) []refs.NamespacedName {
+	return findRefs(obj, providerKind)
+}
+
+// Malformed expressions are ignored, they are reported when the object is validated or resolved.
+func findRefs(obj runtime.Object, kind string) []refs.NamespacedName {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil
+	}
+
+	text, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+
+	found := make([]refs.NamespacedName, 0)
+	seen := make(map[string]bool)
+	add := func(refKind string, ref refs.NamespacedName) {
+		if refKind == kind && !seen[ref.String()] {
+			seen[ref.String()] = true
+			found = append(found, ref)
+		}
+	}
+	collect := func(refKind string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			if ref, _, parseErr := parseRef(refKind, name, accessor.GetNamespace()); parseErr == nil {
+				add(refKind, ref)
+			}
+			return "", nil
+		}
+	}
+	collectProvider := func(name, path, _ string) (string, error) {
+		if ref, parseErr := parseProviderRef(name, path, accessor.GetNamespace()); parseErr == nil {
+			add(providerKind, ref)
+		}
+		return "", nil
+	}
+
+	funcs := staticFuncMap(collect(configMapKind), collect(secretKind), collectProvider)
+	tmpl, err := template.New("gko").Funcs(funcs).Delims("[[", "]]").Parse(string(text))
+	if err != nil {
+		return nil
+	}
+
+	if err = tmpl.Execute(io.Discard, make(map[string]string)); err != nil {
+		return nil
+	}
+
+	return found
+}
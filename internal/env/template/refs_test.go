@@ -0,0 +1,70 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	v2 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v2"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Refs", func() {
+	newApi := func(name, description string) *gio.ApiDefinition {
+		return &gio.ApiDefinition{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api"},
+			Spec: gio.ApiDefinitionSpec{
+				Api: v2.Api{ApiBase: &base.ApiBase{Name: name, Description: description}},
+			},
+		}
+	}
+
+	It("Should find the config maps and secrets used in templates", func() {
+		api := newApi(
+			`[[ configmap "api-config/name" ]]`,
+			`[[ secret "api-secrets/description" ]] [[ configmap "api-config/description" ]]`,
+		)
+
+		Expect(ConfigMapRefs(api)).To(Equal([]refs.NamespacedName{refs.NewNamespacedName("default", "api-config")}))
+		Expect(SecretRefs(api)).To(Equal([]refs.NamespacedName{refs.NewNamespacedName("default", "api-secrets")}))
+	})
+
+	It("Should find the config maps and secrets used in other namespaces", func() {
+		api := newApi(
+			`[[ configmap "shared/api-config/name" | default "api" ]]`,
+			`[[ secret "shared/api-secrets/description" | required "description is missing" ]]`,
+		)
+
+		Expect(ConfigMapRefs(api)).To(Equal([]refs.NamespacedName{refs.NewNamespacedName("shared", "api-config")}))
+		Expect(SecretRefs(api)).To(Equal([]refs.NamespacedName{refs.NewNamespacedName("shared", "api-secrets")}))
+	})
+
+	It("Should not find any reference without templates", func() {
+		api := newApi("api", "no templates here")
+
+		Expect(ConfigMapRefs(api)).To(BeEmpty())
+		Expect(SecretRefs(api)).To(BeEmpty())
+	})
+
+	It("Should ignore malformed references", func() {
+		api := newApi(`[[ secret "api-secrets" ]]`, `[[ secret "shared/api-secrets/description/extra" ]]`)
+
+		Expect(SecretRefs(api)).To(BeEmpty())
+	})
+})
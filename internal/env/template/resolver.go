@@ -22,9 +22,13 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/secretprovider"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	netv1 "k8s.io/api/networking/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/api/meta"
 	util "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/go-logr/logr"
@@ -36,8 +40,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// example my-configmap/key1.
-const ksPropertyLength = 2
+// example my-configmap/key1, or my-namespace/my-configmap/key1.
+const (
+	ksPropertyLength           = 2
+	ksNamespacedPropertyLength = 3
+)
 
 type Resolver struct {
 	ctx    context.Context
@@ -52,8 +59,12 @@ func NewResolver(ctx context.Context, c client.Client, l logr.Logger, obj runtim
 
 func (r *Resolver) Resolve() error {
 	switch t := r.obj.(type) {
-	case *gio.ApiDefinition, *gio.ManagementContext, *gio.Application, *netv1.Ingress, *gio.ApiResource:
-		return r.exec()
+	case *gio.ApiDefinition, *gio.ApiV4Definition, *gio.ManagementContext, *gio.Application,
+		*netv1.Ingress, *gio.ApiResource:
+		_, span := tracing.Start(r.ctx, "template.Resolve", tracing.String("gko.type", fmt.Sprintf("%T", t)))
+		err := r.exec()
+		span.End(err)
+		return err
 	default:
 		return fmt.Errorf("unsupported object type %v", t)
 	}
@@ -65,11 +76,8 @@ func (r *Resolver) exec() error {
 		return err
 	}
 
-	funcMap := map[string]interface{}{
-		"configmap": r.resolveConfigmap,
-		"secret":    r.resolveSecret,
-	}
-	tmpl, err := template.New("gko").Funcs(template.FuncMap(funcMap)).Delims("[[", "]]").Parse(string(text))
+	funcs := funcMap(r.resolveConfigmap, r.resolveSecret, r.resolveProvider)
+	tmpl, err := template.New("gko").Funcs(funcs).Delims("[[", "]]").Parse(string(text))
 	if err != nil {
 		return err
 	}
@@ -88,22 +96,11 @@ func (r *Resolver) exec() error {
 }
 
 func (r *Resolver) resolveConfigmap(name string) (string, error) {
-	if name == "" {
-		return "", fmt.Errorf("empty configmap name")
-	}
-
-	sp := strings.Split(name, "/")
-	if len(sp) != ksPropertyLength {
-		return "", fmt.Errorf("wrong configmap name. Example my-configmap/key1")
-	}
-
-	innerObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(r.obj)
+	nn, key, err := r.lookupRef(configMapKind, name)
 	if err != nil {
 		return "", err
 	}
 
-	u := unstructured.Unstructured{Object: innerObj}
-	nn := types.NamespacedName{Namespace: u.GetNamespace(), Name: sp[0]}
 	cm := new(v1.ConfigMap)
 	if err = r.client.Get(r.ctx, nn, cm); err != nil {
 		return "", err
@@ -113,26 +110,15 @@ func (r *Resolver) resolveConfigmap(name string) (string, error) {
 		return "", err
 	}
 
-	return cm.Data[sp[1]], nil
+	return cm.Data[key], nil
 }
 
 func (r *Resolver) resolveSecret(name string) (string, error) {
-	if name == "" {
-		return "", fmt.Errorf("empty secret name")
-	}
-
-	sp := strings.Split(name, "/")
-	if len(sp) != ksPropertyLength {
-		return "", fmt.Errorf("wrong secret name. Example my-secret/key1")
-	}
-
-	innerObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(r.obj)
+	nn, key, err := r.lookupRef(secretKind, name)
 	if err != nil {
 		return "", err
 	}
 
-	u := unstructured.Unstructured{Object: innerObj}
-	nn := types.NamespacedName{Namespace: u.GetNamespace(), Name: sp[0]}
 	sec := new(v1.Secret)
 	if err = r.client.Get(r.ctx, nn, sec); err != nil {
 		return "", err
@@ -142,7 +128,104 @@ func (r *Resolver) resolveSecret(name string) (string, error) {
 		return "", err
 	}
 
-	return string(sec.Data[sp[1]]), nil
+	return string(sec.Data[key]), nil
+}
+
+func (r *Resolver) resolveProvider(name, path, key string) (string, error) {
+	accessor, err := meta.Accessor(r.obj)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := parseProviderRef(name, path, accessor.GetNamespace())
+	if err != nil {
+		return "", err
+	}
+
+	return secretprovider.Lookup(r.ctx, r.client, ref, path, key)
+}
+
+// lookupRef parses a template reference and checks that the object being resolved
+// is allowed to use it when it designates another namespace.
+func (r *Resolver) lookupRef(kind, name string) (types.NamespacedName, string, error) {
+	accessor, err := meta.Accessor(r.obj)
+	if err != nil {
+		return types.NamespacedName{}, "", err
+	}
+
+	ref, key, err := parseRef(kind, name, accessor.GetNamespace())
+	if err != nil {
+		return types.NamespacedName{}, "", err
+	}
+
+	if ref.Namespace != accessor.GetNamespace() {
+		if err = r.checkReferenceGrant(ref.Namespace, accessor.GetNamespace()); err != nil {
+			return types.NamespacedName{}, "", err
+		}
+	}
+
+	return ref.ToK8sType(), key, nil
+}
+
+// checkReferenceGrant checks that the target namespace allows the source namespace
+// to use its config maps and secrets in templates.
+func (r *Resolver) checkReferenceGrant(target, source string) error {
+	if env.Config.NS != "" {
+		return fmt.Errorf("references to namespace %s are not supported when watching a single namespace", target)
+	}
+
+	ns := new(v1.Namespace)
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: target}, ns); err != nil {
+		return err
+	}
+
+	for _, allowed := range strings.Split(ns.Annotations[keys.TemplateReferencesAnnotation], ",") {
+		if allowed = strings.TrimSpace(allowed); allowed == "*" || allowed == source {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"namespace %s does not allow namespace %s to reference it in templates (see the %s annotation)",
+		target, source, keys.TemplateReferencesAnnotation,
+	)
+}
+
+// parseRef parses a template reference (e.g. my-secret/key1 or my-namespace/my-secret/key1)
+// into a resource name and a key, the resource being in the given namespace if none is set.
+func parseRef(kind, name, namespace string) (refs.NamespacedName, string, error) {
+	if name == "" {
+		return refs.NamespacedName{}, "", fmt.Errorf("empty %s name", kind)
+	}
+
+	sp := strings.Split(name, "/")
+	if len(sp) == ksNamespacedPropertyLength && sp[0] != "" {
+		namespace, sp = sp[0], sp[1:]
+	}
+
+	if len(sp) != ksPropertyLength || sp[0] == "" || sp[1] == "" {
+		return refs.NamespacedName{}, "", fmt.Errorf(
+			"wrong %s name. Example my-%s/key1 or my-namespace/my-%s/key1", kind, kind, kind,
+		)
+	}
+
+	return refs.NewNamespacedName(namespace, sp[0]), sp[1], nil
+}
+
+// parseProviderRef parses the name of a secret provider, which must be in the given namespace.
+func parseProviderRef(name, path, namespace string) (refs.NamespacedName, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return refs.NamespacedName{}, fmt.Errorf(
+			"wrong %s name %q. Example [[ %s \"my-provider\" \"path/to/secret\" \"key1\" ]]",
+			providerKind, name, providerKind,
+		)
+	}
+
+	if path == "" {
+		return refs.NamespacedName{}, fmt.Errorf("empty path for %s %s", providerKind, name)
+	}
+
+	return refs.NewNamespacedName(namespace, name), nil
 }
 
 func (r *Resolver) addFinalizer(obj client.Object) error {
@@ -0,0 +1,113 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	v2 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v2"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Resolver", func() {
+	newSecret := func(namespace string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "api-secrets"},
+			Data:       map[string][]byte{"name": []byte("secret-api"), "encoded": []byte("c2VjcmV0")},
+		}
+	}
+
+	newNamespace := func(name, allowed string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{keys.TemplateReferencesAnnotation: allowed},
+		}}
+	}
+
+	resolve := func(name, description string, objects ...client.Object) (*gio.ApiDefinition, error) {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gio.AddToScheme(scheme)).To(Succeed())
+		k8s := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		api := &gio.ApiDefinition{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api"},
+			Spec: gio.ApiDefinitionSpec{
+				Api: v2.Api{ApiBase: &base.ApiBase{Name: name, Description: description}},
+			},
+		}
+		return api, NewResolver(context.Background(), k8s, logr.Discard(), api).Resolve()
+	}
+
+	It("Should resolve values with functions", func() {
+		api, err := resolve(
+			`[[ secret "api-secrets/encoded" | b64dec ]]`,
+			`[[ secret "api-secrets/missing" | default "fallback" ]] [[ "value" | b64enc ]]`,
+			newSecret("default"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.Spec.Name).To(Equal("secret"))
+		Expect(api.Spec.Description).To(Equal("fallback dmFsdWU="))
+	})
+
+	It("Should fail with the message of a required value", func() {
+		_, err := resolve(`[[ secret "api-secrets/missing" | required "missing is not set" ]]`, "", newSecret("default"))
+		Expect(err).To(MatchError(ContainSubstring("missing is not set")))
+	})
+
+	It("Should only read the environment variables exposed to templates", func() {
+		vars := env.Config.TemplateEnvVars
+		defer func() { env.Config.TemplateEnvVars = vars }()
+		env.Config.TemplateEnvVars = []string{"GKO_TEST_API_NAME"}
+		GinkgoT().Setenv("GKO_TEST_API_NAME", "env-api")
+
+		api, err := resolve(`[[ env "GKO_TEST_API_NAME" ]]`, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.Spec.Name).To(Equal("env-api"))
+
+		_, err = resolve(`[[ env "HOME" ]]`, "")
+		Expect(err).To(MatchError(ContainSubstring("not exposed to templates")))
+	})
+
+	It("Should resolve a reference to a namespace allowing it", func() {
+		api, err := resolve(
+			`[[ secret "shared/api-secrets/name" ]]`, "",
+			newSecret("shared"), newNamespace("shared", "team-a, default"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.Spec.Name).To(Equal("secret-api"))
+	})
+
+	It("Should not resolve a reference to a namespace not allowing it", func() {
+		_, err := resolve(
+			`[[ secret "shared/api-secrets/name" ]]`, "",
+			newSecret("shared"), newNamespace("shared", "team-a"),
+		)
+		Expect(err).To(MatchError(ContainSubstring("does not allow namespace default")))
+	})
+})
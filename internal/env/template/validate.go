@@ -0,0 +1,57 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Validate checks that the template expressions of the given object
+// (e.g. [[ secret "my-secret/key1" ]]) are well formed, without resolving them.
+func Validate(obj runtime.Object) error {
+	text, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	funcs := staticFuncMap(validateRef(configMapKind), validateRef(secretKind), validateProviderRef)
+	tmpl, err := template.New("gko").Funcs(funcs).Delims("[[", "]]").Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("malformed template expression: %w", err)
+	}
+
+	if err = tmpl.Execute(io.Discard, make(map[string]string)); err != nil {
+		return fmt.Errorf("malformed template expression: %w", err)
+	}
+
+	return nil
+}
+
+func validateRef(kind string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		_, _, err := parseRef(kind, name, "")
+		return "", err
+	}
+}
+
+func validateProviderRef(name, path, _ string) (string, error) {
+	_, err := parseProviderRef(name, path, "")
+	return "", err
+}
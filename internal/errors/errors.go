@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 type ServerError struct {
@@ -99,6 +100,30 @@ func FromNewRequestError(method, url string, err error) error {
 	)
 }
 
+// CircuitOpenError is returned without performing the request when too many requests
+// to the same APIM instance have failed in a row.
+type CircuitOpenError struct {
+	Host       string
+	RetryAfter time.Duration
+}
+
+func (err CircuitOpenError) Error() string {
+	return fmt.Sprintf(
+		"requests to %s are suspended after too many consecutive failures, retrying in %s",
+		err.Host, err.RetryAfter.Round(time.Second),
+	)
+}
+
+// IsCircuitOpen returns true if err was caused by an open circuit breaker,
+// along with the time after which requests will be attempted again.
+func IsCircuitOpen(err error) (time.Duration, bool) {
+	circuitError := &CircuitOpenError{}
+	if errors.As(err, circuitError) {
+		return circuitError.RetryAfter, true
+	}
+	return 0, false
+}
+
 func NewNotFoundError() error {
 	return ServerError{StatusCode: http.StatusNotFound}
 }
@@ -15,6 +15,8 @@
 package event
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 )
@@ -58,6 +60,13 @@ var EventDetails = map[Action]Detail{
 	},
 }
 
+const (
+	DriftDetectedReason  = "DriftDetected"
+	DriftCorrectedReason = "DriftCorrected"
+	DryRunReason         = "DryRun"
+	PausedReason         = "Paused"
+)
+
 type Recorder struct {
 	k8sEventRecorder record.EventRecorder
 }
@@ -87,6 +96,31 @@ func (e *Recorder) Record(action Action, obj runtime.Object, do func() error) er
 	return err
 }
 
+// RecordDrift records the differences found between a resource and its state in APIM,
+// as a normal event if they are about to be corrected, as a warning otherwise.
+func (e *Recorder) RecordDrift(obj runtime.Object, drift []string, corrected bool) {
+	message := strings.Join(drift, ", ")
+	if corrected {
+		e.info(obj, DriftCorrectedReason, message)
+	} else {
+		e.warn(obj, DriftDetectedReason, message)
+	}
+}
+
+// RecordDryRun reports the changes a dry run found for the given management context.
+func (e *Recorder) RecordDryRun(obj runtime.Object, context string, changes []string) {
+	message := "no changes"
+	if len(changes) > 0 {
+		message = strings.Join(changes, ", ")
+	}
+	e.info(obj, DryRunReason, context+": "+message)
+}
+
+// RecordPaused reports why the reconciliation of the object has been skipped.
+func (e *Recorder) RecordPaused(obj runtime.Object, reason string) {
+	e.info(obj, PausedReason, "Reconcile paused: "+reason)
+}
+
 func (e *Recorder) info(obj runtime.Object, reason string, message string) {
 	e.k8sEventRecorder.Event(obj, string(Normal), reason, message)
 }
@@ -0,0 +1,188 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export turns existing APIM APIs and applications into custom resources
+// that adopt them once applied, so that they can be managed by the operator without being created again.
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	v2 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v2"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/application"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const activeStatus = "ACTIVE"
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Exporter exports the APIs and applications of the APIM environment targeted by a management context.
+type Exporter struct {
+	apim       *apim.APIM
+	contextRef refs.NamespacedName
+	namespace  string
+	names      map[string]int
+}
+
+func New(apim *apim.APIM, contextRef refs.NamespacedName, namespace string) *Exporter {
+	return &Exporter{
+		apim:       apim,
+		contextRef: contextRef,
+		namespace:  namespace,
+		names:      make(map[string]int),
+	}
+}
+
+// APIs returns an API definition for each API of the environment.
+func (e *Exporter) APIs() ([]*gio.ApiDefinition, error) {
+	items, err := e.apim.APIs.List()
+	if err != nil {
+		return nil, err
+	}
+
+	apis := make([]*gio.ApiDefinition, 0, len(items))
+	for _, item := range items {
+		api, exportErr := e.apim.APIs.Export(item.Id)
+		if exportErr != nil {
+			return nil, fmt.Errorf("unable to export API %s: %w", item.Id, exportErr)
+		}
+		apis = append(apis, ApiDefinition(api, item.Id, e.name("ApiDefinition", item.Name), e.namespace, e.contextRef))
+	}
+
+	return apis, nil
+}
+
+// Applications returns an application for each active application of the environment.
+func (e *Exporter) Applications() ([]*gio.Application, error) {
+	items, err := e.apim.Applications.Search("", activeStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]*gio.Application, 0, len(items))
+	for i := range items {
+		app := &items[i]
+		apps = append(apps, Application(app, e.name("Application", app.Name), e.namespace, e.contextRef))
+	}
+
+	return apps, nil
+}
+
+// ApiDefinition returns an API definition adopting the given exported API once applied.
+// Plans are kept with their IDs so that existing subscriptions are preserved.
+func ApiDefinition(
+	api *v2.Api, id, name, namespace string, contextRef refs.NamespacedName,
+) *gio.ApiDefinition {
+	spec := api.DeepCopy()
+	if spec.ApiBase != nil {
+		spec.ID = ""
+		spec.DefinitionContext = nil
+		spec.PrimaryOwner = nil
+	}
+
+	return &gio.ApiDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gio.GroupVersion.String(), Kind: "ApiDefinition"},
+		ObjectMeta: objectMeta(id, name, namespace),
+		Spec: gio.ApiDefinitionSpec{
+			Api:     *spec,
+			Context: &contextRef,
+			IsLocal: false,
+		},
+	}
+}
+
+// Application returns an application adopting the given APIM application once applied.
+func Application(app *model.Application, name, namespace string, contextRef refs.NamespacedName) *gio.Application {
+	return &gio.Application{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gio.GroupVersion.String(), Kind: "Application"},
+		ObjectMeta: objectMeta(app.Id, name, namespace),
+		Spec: gio.ApplicationSpec{
+			Application: application.Application{
+				Name:            app.Name,
+				Description:     app.Description,
+				ApplicationType: app.AppType,
+				Background:      app.Background,
+				Domain:          app.Domain,
+				Groups:          app.Groups,
+				Picture:         app.Picture,
+				Settings:        app.Settings,
+				AppKeyMode:      app.AppKeyMode,
+			},
+			Context: &contextRef,
+		},
+	}
+}
+
+// ToYAML returns the given custom resources as a multi-document YAML, ready to be applied.
+func ToYAML(objs ...runtime.Object) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	for _, obj := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(content, "status")
+		if metadata, ok := content["metadata"].(map[string]any); ok {
+			delete(metadata, "creationTimestamp")
+		}
+
+		b, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(b)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func objectMeta(id, name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Annotations: map[string]string{keys.AdoptAnnotation: id},
+	}
+}
+
+// name returns a valid kubernetes name for the given APIM name,
+// suffixed when needed to be unique among the exported resources of the same kind.
+func (e *Exporter) name(kind, apimName string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(apimName), "-"), "-")
+	if name == "" {
+		name = "exported"
+	}
+
+	key := kind + "/" + name
+	e.names[key]++
+	if count := e.names[key]; count > 1 {
+		return fmt.Sprintf("%s-%d", name, count)
+	}
+
+	return name
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	v2 "github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/v2"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	"sigs.k8s.io/yaml"
+)
+
+var contextRef = refs.NamespacedName{Namespace: "default", Name: "dev-ctx"}
+
+var _ = Describe("Export", func() {
+	It("Should export an API adopting the APIM API", func() {
+		api := &v2.Api{
+			ApiBase: &base.ApiBase{
+				ID:                "api-id",
+				CrossID:           "api-cross-id",
+				Name:              "My API",
+				DefinitionContext: &base.DefinitionContext{Origin: "management"},
+			},
+			Plans: []*v2.Plan{
+				{Plan: &base.Plan{Id: "plan-id", CrossId: "plan-cross-id", Name: "free"}, Security: "KEY_LESS"},
+			},
+		}
+
+		exported := ApiDefinition(api, "api-id", "my-api", "apis", contextRef)
+
+		Expect(exported.Name).To(Equal("my-api"))
+		Expect(exported.Namespace).To(Equal("apis"))
+		Expect(exported.Annotations).To(HaveKeyWithValue(keys.AdoptAnnotation, "api-id"))
+		Expect(exported.Spec.Context).To(Equal(&contextRef))
+		Expect(exported.Spec.IsLocal).To(BeFalse())
+		Expect(exported.Spec.ID).To(BeEmpty())
+		Expect(exported.Spec.CrossID).To(Equal("api-cross-id"))
+		Expect(exported.Spec.DefinitionContext).To(BeNil())
+		Expect(exported.Spec.Plans[0].Id).To(Equal("plan-id"))
+		Expect(exported.Spec.Plans[0].CrossId).To(Equal("plan-cross-id"))
+		Expect(api.ID).To(Equal("api-id"))
+	})
+
+	It("Should export an application adopting the APIM application", func() {
+		app := &model.Application{Id: "app-id", Name: "My App", Description: "An app", AppType: "SIMPLE"}
+
+		exported := Application(app, "my-app", "apps", contextRef)
+
+		Expect(exported.Annotations).To(HaveKeyWithValue(keys.AdoptAnnotation, "app-id"))
+		Expect(exported.Spec.Name).To(Equal("My App"))
+		Expect(exported.Spec.ApplicationType).To(Equal("SIMPLE"))
+		Expect(exported.Spec.ID).To(BeEmpty())
+	})
+
+	It("Should generate unique kubernetes names", func() {
+		exporter := New(nil, contextRef, "default")
+
+		Expect(exporter.name("ApiDefinition", "My API (v2)")).To(Equal("my-api-v2"))
+		Expect(exporter.name("ApiDefinition", "my api v2")).To(Equal("my-api-v2-2"))
+		Expect(exporter.name("Application", "My API v2")).To(Equal("my-api-v2"))
+		Expect(exporter.name("Application", "***")).To(Equal("exported"))
+	})
+
+	It("Should export resources as YAML documents without status", func() {
+		app := Application(&model.Application{Id: "app-id", Name: "app"}, "app", "default", contextRef)
+
+		out, err := ToYAML(app, app)
+		Expect(err).ToNot(HaveOccurred())
+
+		content := string(out)
+		Expect(content).ToNot(ContainSubstring("status"))
+		Expect(content).ToNot(ContainSubstring("creationTimestamp"))
+
+		documents := strings.Split(content, "---\n")
+		Expect(documents).To(HaveLen(3))
+		Expect(documents[0]).To(BeEmpty())
+
+		parsed := new(gio.Application)
+		Expect(yaml.Unmarshal([]byte(documents[1]), parsed)).To(Succeed())
+		Expect(parsed.Kind).To(Equal("Application"))
+		Expect(parsed.Annotations).To(HaveKeyWithValue(keys.AdoptAnnotation, "app-id"))
+	})
+})
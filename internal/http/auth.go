@@ -32,8 +32,9 @@ type BasicAuth struct {
 }
 
 type Auth struct {
-	Basic *BasicAuth
-	Token BearerToken
+	Basic  *BasicAuth
+	Token  BearerToken
+	OAuth2 *OAuth2
 }
 
 type AuthenticatedRoundTripper struct {
@@ -41,22 +42,45 @@ type AuthenticatedRoundTripper struct {
 	transport http.RoundTripper
 }
 
-func (auth *Auth) Authenticate(req *http.Request) {
+// Authenticate sets the authorization header of the request. When using OAuth2,
+// the access token is fetched from the token endpoint using transport.
+func (auth *Auth) Authenticate(req *http.Request, transport http.RoundTripper) error {
 	bearer := auth.Token
 	basic := auth.Basic
 
-	if bearer != "" {
+	if auth.OAuth2 != nil {
+		token, err := auth.OAuth2.Token(transport)
+		if err != nil {
+			return err
+		}
+		token.SetAuthHeader(req)
+	} else if bearer != "" {
 		req.Header.Add(AuthorizationHeader, bearer.String())
 	} else if basic != nil {
 		req.SetBasicAuth(basic.Username, basic.Password)
 	}
+
+	return nil
 }
 
 func (t *AuthenticatedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	t.auth.Authenticate(req)
+	if err := t.auth.Authenticate(req, t.transport); err != nil {
+		return nil, err
+	}
 	return t.transport.RoundTrip(req)
 }
 
+func (t *AuthenticatedRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(t.transport)
+}
+
+// closeIdleConnections closes the idle connections of the transport if it supports it.
+func closeIdleConnections(transport http.RoundTripper) {
+	if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
 func NewAuthenticatedRoundTripper(
 	auth *Auth,
 	transport http.RoundTripper,
@@ -0,0 +1,133 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+// Breaker stops sending requests to an APIM instance after a number of consecutive failures.
+// Once the cooldown has elapsed, a single request is let through to probe the instance,
+// closing the breaker if it succeeds or opening it again if it fails.
+// A nil breaker lets all requests through.
+type Breaker struct {
+	mu        sync.Mutex
+	host      string
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+	onChange  func(open bool)
+	now       func() time.Time
+}
+
+// NewBreaker returns a breaker opening for cooldown after threshold consecutive failures.
+// onChange is called each time the breaker opens or closes and may be nil.
+func NewBreaker(host string, threshold int, cooldown time.Duration, onChange func(open bool)) *Breaker {
+	return &Breaker{
+		host:      host,
+		threshold: threshold,
+		cooldown:  cooldown,
+		onChange:  onChange,
+		now:       time.Now,
+	}
+}
+
+// Allow returns a CircuitOpenError if requests should not be sent.
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.isOpen() {
+		return nil
+	}
+
+	if remaining := b.openUntil.Sub(b.now()); remaining > 0 {
+		return errors.CircuitOpenError{Host: b.host, RetryAfter: remaining}
+	}
+
+	if b.probing {
+		return errors.CircuitOpenError{Host: b.host, RetryAfter: b.cooldown}
+	}
+
+	b.probing = true
+	return nil
+}
+
+// Record registers the outcome of a request allowed by the breaker.
+func (b *Breaker) Record(failed bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasOpen := b.isOpen()
+	b.probing = false
+
+	if !failed {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		b.notify(wasOpen, false)
+		return
+	}
+
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		b.notify(wasOpen, true)
+	}
+}
+
+// Err returns a CircuitOpenError if the breaker is open, until a request succeeds.
+// Unlike Allow, it does not let a probe request through.
+func (b *Breaker) Err() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.isOpen() {
+		return nil
+	}
+
+	remaining := b.openUntil.Sub(b.now())
+	if remaining <= 0 {
+		remaining = b.cooldown
+	}
+
+	return errors.CircuitOpenError{Host: b.host, RetryAfter: remaining}
+}
+
+func (b *Breaker) isOpen() bool {
+	return !b.openUntil.IsZero()
+}
+
+func (b *Breaker) notify(wasOpen, open bool) {
+	if wasOpen != open && b.onChange != nil {
+		go b.onChange(open)
+	}
+}
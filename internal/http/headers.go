@@ -17,6 +17,7 @@ package http
 const (
 	AuthorizationHeader  = "Authorization"
 	ContentTypeHeader    = "Content-Type"
+	RetryAfterHeader     = "Retry-After"
 	ContentTypeTextPlain = "text/plain"
 	ContentTypeJSON      = "application/json"
 )
@@ -25,13 +25,25 @@ import (
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
 )
 
-const (
-	requestTimeoutSeconds = 5
-)
-
 type Client struct {
-	ctx  context.Context
-	http http.Client
+	ctx        context.Context
+	name       string
+	http       http.Client
+	breaker    *Breaker
+	limits     []Limit
+	maxRetries int
+}
+
+// WithLimits returns the client, waiting for the limits before sending each request.
+func (client *Client) WithLimits(limits ...Limit) *Client {
+	client.limits = append(client.limits, limits...)
+	return client
+}
+
+// WithBreaker returns the client, rejecting requests while the breaker is open.
+func (client *Client) WithBreaker(breaker *Breaker) *Client {
+	client.breaker = breaker
+	return client
 }
 
 func (client *Client) Context() context.Context {
@@ -111,11 +123,12 @@ func (client *Client) Delete(url string, target any, transformers ...RequestTran
 }
 
 func (client *Client) do(req *http.Request, target any) error {
-	resp, err := client.http.Do(req)
+	resp, release, err := client.doWithRetry(req)
 	if err != nil {
-		return errors.FromDoRequestError(req, err)
+		return err
 	}
 
+	defer release()
 	defer resp.Body.Close()
 
 	if err = errors.FromResponse(resp); err != nil {
@@ -125,6 +138,45 @@ func (client *Client) do(req *http.Request, target any) error {
 	return WriteJSON(resp, target)
 }
 
+// doWithRetry returns the response of the last attempt and a function releasing
+// the limits acquired for it, to be called once the response has been read.
+func (client *Client) doWithRetry(req *http.Request) (*http.Response, func(), error) {
+	for attempt := 1; ; attempt++ {
+		release, err := acquire(req.Context(), client.limits)
+		if err != nil {
+			return nil, nil, errors.FromDoRequestError(req, err)
+		}
+
+		if err = client.breaker.Allow(); err != nil {
+			release()
+			return nil, nil, err
+		}
+
+		start := time.Now()
+		resp, err := client.http.Do(req)
+		client.observe(req, resp, start)
+		client.breaker.Record(isFailure(req, resp, err))
+
+		delay, retry := retryDelay(req, resp, err, attempt, client.maxRetries)
+		if !retry && err != nil {
+			release()
+			return nil, nil, errors.FromDoRequestError(req, err)
+		}
+		if !retry {
+			return resp, release, nil
+		}
+
+		discard(resp)
+		release()
+		if err = rewind(req); err != nil {
+			return nil, nil, errors.FromDoRequestError(req, err)
+		}
+		if err = wait(req.Context(), delay); err != nil {
+			return nil, nil, errors.FromDoRequestError(req, err)
+		}
+	}
+}
+
 func (client *Client) preparePost(url string, entity any) (*http.Request, error) {
 	if entity == nil {
 		return http.NewRequestWithContext(client.ctx, http.MethodPost, url, nil)
@@ -160,19 +212,42 @@ func (client *Client) newJSONRequest(method, url string, entity any) (*http.Requ
 	return req, nil
 }
 
-func NewClient(ctx context.Context, auth *Auth) *Client {
+// NewClient returns a new client using the given authentication and TLS configuration.
+// If tlsConfig is nil, the server certificate is verified against the system trust store.
+func NewClient(ctx context.Context, auth *Auth, tlsConfig *tls.Config) *Client {
+	return NewClientWithTransport(ctx, NewTransport(auth, tlsConfig))
+}
+
+// NewClientWithTransport returns a new client sending requests using transport,
+// which can be shared between clients to keep connections alive.
+func NewClientWithTransport(ctx context.Context, transport http.RoundTripper) *Client {
+	httpClient := http.Client{Timeout: requestTimeout(), Transport: traced(transport)}
+	return &Client{ctx: ctx, http: httpClient, maxRetries: env.Config.HttpClientMaxRetries}
+}
+
+// NewTransport returns a transport using the given authentication and TLS configuration.
+// If tlsConfig is nil, the server certificate is verified against the system trust store.
+func NewTransport(auth *Auth, tlsConfig *tls.Config) http.RoundTripper {
 	transport := http.DefaultTransport.(*http.Transport).Clone()
-	// #nosec G402
-	transport.TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: env.Config.InsecureSkipVerify,
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
 	}
+	// #nosec G402
+	tlsConfig.InsecureSkipVerify = env.Config.InsecureSkipVerify
+	transport.TLSClientConfig = tlsConfig
 
-	httpClient := http.Client{Timeout: requestTimeoutSeconds * time.Second, Transport: transport}
+	// Keep enough connections alive for all the requests allowed concurrently
+	if env.Config.HttpClientConcurrency > http.DefaultMaxIdleConnsPerHost {
+		transport.MaxIdleConnsPerHost = env.Config.HttpClientConcurrency
+	}
 
-	if auth != nil {
-		authRoundTripper := NewAuthenticatedRoundTripper(auth, transport)
-		httpClient.Transport = authRoundTripper
+	if auth == nil {
+		return transport
 	}
 
-	return &Client{ctx, httpClient}
+	return NewAuthenticatedRoundTripper(auth, transport)
+}
+
+func requestTimeout() time.Duration {
+	return time.Duration(env.Config.HttpClientTimeout) * time.Second
 }
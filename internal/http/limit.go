@@ -0,0 +1,117 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit delays requests until they can be sent. Requests wait for a limit
+// before the request timeout starts, so that waiting never fails a request.
+type Limit interface {
+	// Acquire blocks until a request can be sent, or returns an error if ctx is done first.
+	Acquire(ctx context.Context) error
+	// Release is called once the response of a request allowed by Acquire has been read.
+	Release()
+}
+
+// Limiter bounds the number of requests in flight. A nil limiter does not bound requests.
+type Limiter struct {
+	slots chan struct{}
+}
+
+var _ Limit = &Limiter{}
+
+// NewLimiter returns a limiter allowing size requests in flight,
+// or nil if size is not positive.
+func NewLimiter(size int) *Limiter {
+	if size <= 0 {
+		return nil
+	}
+	return &Limiter{slots: make(chan struct{}, size)}
+}
+
+func (l *Limiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) Release() {
+	if l == nil {
+		return
+	}
+
+	<-l.slots
+}
+
+// RateLimiter bounds the rate at which requests are sent using a token bucket.
+// A nil rate limiter does not bound requests.
+type RateLimiter struct {
+	limiter *rate.Limiter
+}
+
+var _ Limit = &RateLimiter{}
+
+// NewRateLimiter returns a rate limiter allowing requestsPerSecond requests per second
+// with bursts of up to burst requests, or nil if requestsPerSecond is not positive.
+func NewRateLimiter(requestsPerSecond, burst int) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = requestsPerSecond
+	}
+	return &RateLimiter{limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst)}
+}
+
+func (l *RateLimiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	return l.limiter.Wait(ctx)
+}
+
+func (l *RateLimiter) Release() {}
+
+// acquire waits for all the limits, returning a function releasing them.
+func acquire(ctx context.Context, limits []Limit) (func(), error) {
+	acquired := make([]Limit, 0, len(limits))
+	release := func() {
+		for _, limit := range acquired {
+			limit.Release()
+		}
+	}
+
+	for _, limit := range limits {
+		if err := limit.Acquire(ctx); err != nil {
+			release()
+			return nil, err
+		}
+		acquired = append(acquired, limit)
+	}
+
+	return release, nil
+}
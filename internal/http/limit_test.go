@@ -0,0 +1,110 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Limits", func() {
+	var current, peak atomic.Int32
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		current.Store(0)
+		peak.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count := current.Add(1)
+			defer current.Add(-1)
+			for {
+				highest := peak.Load()
+				if count <= highest || peak.CompareAndSwap(highest, count) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Should bound the number of concurrent requests", func() {
+		transport := NewTransport(nil, nil)
+		limiter := NewLimiter(2)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(NewClientWithTransport(context.Background(), transport).WithLimits(limiter).Get(server.URL, nil)).To(Succeed())
+			}()
+		}
+		wg.Wait()
+
+		Expect(peak.Load()).To(Equal(int32(2)))
+	})
+
+	It("Should not bound requests without a positive size", func() {
+		Expect(NewLimiter(0)).To(BeNil())
+		Expect(NewRateLimiter(0, 0)).To(BeNil())
+		Expect(NewClient(context.Background(), nil, nil).WithLimits(NewLimiter(0), NewRateLimiter(0, 0)).
+			Get(server.URL, nil)).To(Succeed())
+	})
+
+	It("Should delay requests exceeding the rate limit", func() {
+		client := NewClient(context.Background(), nil, nil).WithLimits(NewRateLimiter(20, 1))
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			Expect(client.Get(server.URL, nil)).To(Succeed())
+		}
+		Expect(time.Since(start)).To(BeNumerically(">=", 190*time.Millisecond))
+	})
+
+	It("Should wait for a slot longer than the request timeout", func() {
+		limiter := NewLimiter(1)
+		Expect(limiter.Acquire(context.Background())).To(Succeed())
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			limiter.Release()
+		}()
+
+		client := NewClient(context.Background(), nil, nil).WithLimits(limiter)
+		client.http.Timeout = 50 * time.Millisecond
+		Expect(client.Get(server.URL, nil)).To(Succeed())
+	})
+
+	It("Should stop waiting once the request is canceled", func() {
+		limiter := NewLimiter(1)
+		Expect(limiter.Acquire(context.Background())).To(Succeed())
+		defer limiter.Release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		Expect(NewClient(ctx, nil, nil).WithLimits(limiter).Get(server.URL, nil)).ToNot(Succeed())
+	})
+})
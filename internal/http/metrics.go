@@ -0,0 +1,66 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/metrics"
+)
+
+var idSegment = regexp.MustCompile(
+	`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9]+)$`,
+)
+
+// Segments following these ones are replaced by a named placeholder in the endpoint template.
+var namedSegments = map[string]string{
+	"organizations": "{orgId}",
+	"environments":  "{envId}",
+}
+
+// WithName returns the client, reporting its requests under the given name in metrics.
+func (client *Client) WithName(name string) *Client {
+	client.name = name
+	return client
+}
+
+func (client *Client) observe(req *http.Request, resp *http.Response, start time.Time) {
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	metrics.ObserveAPIMRequest(client.name, req.Method, endpointOf(req), statusCode, time.Since(start))
+}
+
+// endpointOf returns the path of the request with organization, environment and
+// resource IDs replaced by placeholders, keeping the cardinality of metrics low.
+func endpointOf(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i := range segments {
+		if i > 0 {
+			if placeholder, ok := namedSegments[segments[i-1]]; ok && segments[i] != "" {
+				segments[i] = placeholder
+				continue
+			}
+		}
+		if idSegment.MatchString(segments[i]) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
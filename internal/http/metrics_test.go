@@ -0,0 +1,49 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics", func() {
+	DescribeTable("Should template the endpoint of a request",
+		func(url, expected string) {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpointOf(req)).To(Equal(expected))
+		},
+		Entry("with organization and environment",
+			"http://apim/management/organizations/DEFAULT/environments/DEFAULT/apis",
+			"/management/organizations/{orgId}/environments/{envId}/apis",
+		),
+		Entry("with a resource id",
+			"http://apim/management/organizations/DEFAULT/environments/DEFAULT/apis/"+
+				"6c8a2b8e-1c2f-4a1e-8a2b-8e1c2f4a1e8a/deploy",
+			"/management/organizations/{orgId}/environments/{envId}/apis/{id}/deploy",
+		),
+		Entry("with a numeric id and a query",
+			"http://apim/management/organizations/DEFAULT/environments/DEFAULT/apis/42?page=2",
+			"/management/organizations/{orgId}/environments/{envId}/apis/{id}",
+		),
+		Entry("without organization",
+			"http://apim/management/v2/environments/DEFAULT/apis",
+			"/management/v2/environments/{envId}/apis",
+		),
+	)
+})
@@ -0,0 +1,85 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// tokenRefreshDelta is how early before its expiry an access token is refreshed.
+const tokenRefreshDelta = 30 * time.Second
+
+// OAuth2 holds the client credentials used to get an access token from a token endpoint.
+type OAuth2 struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// Token sources are cached by client credentials so that access tokens are reused across
+// the clients created on each reconcile, and refreshed only when they are about to expire.
+var tokenSources = struct {
+	sync.Mutex
+	sources map[string]oauth2.TokenSource
+}{sources: make(map[string]oauth2.TokenSource)}
+
+// Token returns a valid access token, fetching a new one from the token endpoint
+// using transport if the cached one is about to expire.
+func (o *OAuth2) Token(transport http.RoundTripper) (*oauth2.Token, error) {
+	return o.tokenSource(transport).Token()
+}
+
+func (o *OAuth2) tokenSource(transport http.RoundTripper) oauth2.TokenSource {
+	tokenSources.Lock()
+	defer tokenSources.Unlock()
+
+	key := o.key()
+	if source, ok := tokenSources.sources[key]; ok {
+		return source
+	}
+
+	config := clientcredentials.Config{
+		ClientID:     o.ClientID,
+		ClientSecret: o.ClientSecret,
+		TokenURL:     o.TokenURL,
+		Scopes:       o.Scopes,
+	}
+
+	httpClient := &http.Client{Timeout: requestTimeout(), Transport: transport}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	source := oauth2.ReuseTokenSourceWithExpiry(nil, config.TokenSource(ctx), tokenRefreshDelta)
+	tokenSources.sources[key] = source
+
+	return source
+}
+
+// key identifies the credentials, hashing the client secret so that a rotated secret
+// results in a new token source.
+func (o *OAuth2) key() string {
+	hash := sha256.Sum256([]byte(o.ClientSecret))
+	return strings.Join([]string{
+		o.TokenURL, o.ClientID, strings.Join(o.Scopes, " "), hex.EncodeToString(hash[:]),
+	}, "|")
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OAuth2", func() {
+	var tokenRequests atomic.Int32
+	var expiresIn int
+	var idp, apim *httptest.Server
+
+	BeforeEach(func() {
+		tokenRequests.Store(0)
+		idp = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			Expect(r.Form.Get("grant_type")).To(Equal("client_credentials"))
+			count := tokenRequests.Add(1)
+			w.Header().Set(ContentTypeHeader, ContentTypeJSON)
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, count, expiresIn)
+		}))
+		apim = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(ContentTypeHeader, ContentTypeJSON)
+			fmt.Fprintf(w, `{"authorization":%q}`, r.Header.Get(AuthorizationHeader))
+		}))
+	})
+
+	AfterEach(func() {
+		idp.Close()
+		apim.Close()
+	})
+
+	get := func(auth *Auth) string {
+		target := map[string]string{}
+		Expect(NewClient(context.Background(), auth, nil).Get(apim.URL, &target)).To(Succeed())
+		return target["authorization"]
+	}
+
+	It("Should reuse the access token across clients until it expires", func() {
+		expiresIn = 3600
+		auth := &Auth{OAuth2: &OAuth2{TokenURL: idp.URL, ClientID: "gko", ClientSecret: "reused"}}
+
+		Expect(get(auth)).To(Equal("Bearer token-1"))
+		Expect(get(auth)).To(Equal("Bearer token-1"))
+		Expect(tokenRequests.Load()).To(Equal(int32(1)))
+	})
+
+	It("Should refresh the access token before it expires", func() {
+		expiresIn = 10
+		auth := &Auth{OAuth2: &OAuth2{TokenURL: idp.URL, ClientID: "gko", ClientSecret: "refreshed"}}
+
+		Expect(get(auth)).To(Equal("Bearer token-1"))
+		Expect(get(auth)).To(Equal("Bearer token-2"))
+		Expect(tokenRequests.Load()).To(Equal(int32(2)))
+	})
+
+	It("Should get a new access token when the client secret changes", func() {
+		expiresIn = 3600
+		Expect(get(&Auth{OAuth2: &OAuth2{TokenURL: idp.URL, ClientID: "gko", ClientSecret: "old"}})).
+			To(Equal("Bearer token-1"))
+		Expect(get(&Auth{OAuth2: &OAuth2{TokenURL: idp.URL, ClientID: "gko", ClientSecret: "new"}})).
+			To(Equal("Bearer token-2"))
+	})
+})
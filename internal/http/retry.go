@@ -0,0 +1,153 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retryBaseDelay = 250 * time.Millisecond
+	retryMaxDelay  = 5 * time.Second
+	// Retry-After delays above this limit are not waited for,
+	// the error is returned so that the reconcile is requeued instead.
+	maxRetryAfter = 30 * time.Second
+)
+
+// retryDelay returns the time to wait before sending the request again and whether it should be sent again.
+// Connection errors and gateway errors are only retried for idempotent requests, unless the connection
+// could not be established at all. Requests rejected with 429 or 503 are always retried,
+// honouring the Retry-After header if any.
+func retryDelay(req *http.Request, resp *http.Response, err error, attempt, maxRetries int) (time.Duration, bool) {
+	if attempt > maxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+
+	if err != nil {
+		return backoff(attempt), isIdempotent(req.Method) || isDialError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		if delay, ok := parseRetryAfter(resp.Header.Get(RetryAfterHeader)); ok {
+			return delay, delay <= maxRetryAfter
+		}
+		return backoff(attempt), true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return backoff(attempt), isIdempotent(req.Method)
+	default:
+		return 0, false
+	}
+}
+
+// isFailure tells if the outcome of a request means that the APIM instance is unavailable.
+func isFailure(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns an exponential delay with full jitter for the given attempt, starting at 1.
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << (attempt - 1)
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	// #nosec G404 -- jitter does not require a secure random source
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isDialError(err error) bool {
+	opError := &net.OpError{}
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+// rewind prepares a request to be sent again by resetting its body.
+func rewind(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	return nil
+}
+
+func discard(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+)
+
+var _ = Describe("Retry", func() {
+	var requests atomic.Int32
+	var statuses []int
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		requests.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count := int(requests.Add(1))
+			status := statuses[len(statuses)-1]
+			if count <= len(statuses) {
+				status = statuses[count-1]
+			}
+			w.Header().Set(RetryAfterHeader, "0")
+			w.Header().Set(ContentTypeHeader, ContentTypeJSON)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func() *Client {
+		client := NewClient(context.Background(), nil, nil)
+		client.maxRetries = 3
+		return client
+	}
+
+	It("Should retry a request rejected with Retry-After until it succeeds", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+		Expect(newClient().Post(server.URL, map[string]string{"name": "api"}, nil)).To(Succeed())
+		Expect(requests.Load()).To(Equal(int32(3)))
+	})
+
+	It("Should give up after the maximum number of retries", func() {
+		statuses = []int{http.StatusGatewayTimeout}
+		err := newClient().Get(server.URL, nil)
+		Expect(errors.IsServerError(err)).To(BeTrue())
+		Expect(requests.Load()).To(Equal(int32(4)))
+	})
+
+	It("Should not retry a non idempotent request on a gateway error", func() {
+		statuses = []int{http.StatusBadGateway, http.StatusOK}
+		Expect(newClient().Post(server.URL, nil, nil)).ToNot(Succeed())
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("Should not retry a client error", func() {
+		statuses = []int{http.StatusBadRequest, http.StatusOK}
+		Expect(newClient().Put(server.URL, nil, nil)).ToNot(Succeed())
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("Should stop sending requests once the breaker is open", func() {
+		statuses = []int{http.StatusServiceUnavailable}
+		client := newClient().WithBreaker(NewBreaker("apim", 2, time.Minute, nil))
+
+		err := client.Get(server.URL, nil)
+		retryAfter, open := errors.IsCircuitOpen(err)
+		Expect(open).To(BeTrue())
+		Expect(retryAfter).To(BeNumerically(">", 59*time.Second))
+		Expect(requests.Load()).To(Equal(int32(2)))
+	})
+})
+
+var _ = Describe("Breaker", func() {
+	var now time.Time
+	var changes chan bool
+	var breaker *Breaker
+
+	BeforeEach(func() {
+		now = time.Now()
+		changes = make(chan bool, 10)
+		breaker = NewBreaker("apim", 2, time.Minute, func(open bool) { changes <- open })
+		breaker.now = func() time.Time { return now }
+	})
+
+	It("Should open after consecutive failures and close after a successful probe", func() {
+		breaker.Record(true)
+		breaker.Record(false)
+		breaker.Record(true)
+		Expect(breaker.Allow()).To(Succeed())
+		breaker.Record(true)
+		Expect(breaker.Allow()).ToNot(Succeed())
+		Expect(breaker.Err()).ToNot(Succeed())
+		Eventually(changes).Should(Receive(BeTrue()))
+
+		now = now.Add(time.Minute)
+		Expect(breaker.Allow()).To(Succeed())
+		Expect(breaker.Allow()).ToNot(Succeed(), "only one probe request is let through")
+
+		breaker.Record(false)
+		Expect(breaker.Allow()).To(Succeed())
+		Expect(breaker.Err()).To(Succeed())
+		Eventually(changes).Should(Receive(BeFalse()))
+	})
+
+	It("Should open again when the probe fails", func() {
+		breaker.Record(true)
+		breaker.Record(true)
+		Eventually(changes).Should(Receive(BeTrue()))
+
+		now = now.Add(time.Minute)
+		Expect(breaker.Allow()).To(Succeed())
+		breaker.Record(true)
+
+		Expect(breaker.Allow()).ToNot(Succeed())
+		Consistently(changes, 100*time.Millisecond).ShouldNot(Receive())
+	})
+})
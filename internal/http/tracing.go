@@ -0,0 +1,31 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// traced returns the transport, recording a client span for each request attempt
+// and propagating its trace context to the server in the request headers.
+func traced(transport http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(transport, otelhttp.WithSpanNameFormatter(spanName))
+}
+
+func spanName(_ string, req *http.Request) string {
+	return req.Method + " " + endpointOf(req)
+}
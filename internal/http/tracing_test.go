@@ -0,0 +1,71 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
+)
+
+var _ = Describe("Tracing", func() {
+	var traceParent string
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		traceParent = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceParent = r.Header.Get("traceparent")
+			w.Header().Set(ContentTypeHeader, ContentTypeJSON)
+			_, _ = w.Write([]byte(`{}`))
+		}))
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+		server.Close()
+	})
+
+	It("Should not propagate a trace context when tracing is disabled", func() {
+		client := NewClient(context.Background(), nil, nil)
+		Expect(client.Get(server.URL, nil)).To(Succeed())
+		Expect(traceParent).To(BeEmpty())
+	})
+
+	It("Should propagate the trace context of the reconcile to APIM", func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		ctx, _ := tracing.Start(context.Background(), "reconcile")
+		parent := trace.SpanContextFromContext(ctx)
+		client := NewClient(ctx, nil, nil)
+		Expect(client.Get(server.URL+"/apis", nil)).To(Succeed())
+
+		Expect(traceParent).To(MatchRegexp("^00-[0-9a-f]{32}-[0-9a-f]{16}-01$"))
+		Expect(traceParent).To(HavePrefix("00-" + parent.TraceID().String()))
+		Expect(traceParent).ToNot(ContainSubstring(parent.SpanID().String()))
+	})
+})
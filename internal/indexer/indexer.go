@@ -16,6 +16,7 @@ package indexer
 
 import (
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env/template"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 	v1 "k8s.io/api/networking/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,12 +25,17 @@ import (
 type IndexField string
 
 const (
-	ContextField     IndexField = "context"
-	SecretRefField   IndexField = "secretRef"
-	ResourceField    IndexField = "resource"
-	ApiTemplateField IndexField = "api-template"
-	TLSSecretField   IndexField = "tls-secret"
-	AppContextField  IndexField = "app-context"
+	ContextField           IndexField = "context"
+	SecretRefField         IndexField = "secretRef"
+	ResourceField          IndexField = "resource"
+	ApiTemplateField       IndexField = "api-template"
+	TLSSecretField         IndexField = "tls-secret"
+	AppContextField        IndexField = "app-context"
+	SubscriptionApiField   IndexField = "subscription-api"
+	SubscriptionAppField   IndexField = "subscription-app"
+	TemplateConfigMapField IndexField = "template-configmap"
+	TemplateSecretField    IndexField = "template-secret"
+	TemplateProviderField  IndexField = "template-provider"
 )
 
 func (f IndexField) String() string {
@@ -66,16 +72,14 @@ func NewIndexer[T client.Object](field IndexField, doIndex func(T, *[]string)) I
 }
 
 func IndexManagementContexts(api *gio.ApiDefinition, fields *[]string) {
-	if api.Spec.Context == nil {
-		return
+	for _, ref := range api.ContextRefs() {
+		*fields = append(*fields, ref.String())
 	}
-
-	*fields = append(*fields, api.Spec.Context.String())
 }
 
 func IndexManagementContextSecrets(context *gio.ManagementContext, fields *[]string) {
-	if context.Spec.HasSecretRef() {
-		*fields = append(*fields, context.Spec.SecretRef().String())
+	for _, ref := range context.Spec.SecretRefs() {
+		*fields = append(*fields, ref.String())
 	}
 }
 
@@ -120,3 +124,49 @@ func IndexApplicationManagementContexts(application *gio.Application, fields *[]
 
 	*fields = append(*fields, application.Spec.Context.String())
 }
+
+func IndexSubscriptionApiRefs(subscription *gio.Subscription, fields *[]string) {
+	*fields = append(*fields, subscription.ApiRef().String())
+}
+
+func IndexSubscriptionAppRefs(subscription *gio.Subscription, fields *[]string) {
+	*fields = append(*fields, subscription.AppRef().String())
+}
+
+func IndexApiV4ManagementContexts(api *gio.ApiV4Definition, fields *[]string) {
+	if api.Spec.Context == nil {
+		return
+	}
+
+	*fields = append(*fields, api.Spec.Context.String())
+}
+
+func IndexApiV4ResourceRefs(api *gio.ApiV4Definition, fields *[]string) {
+	if api.Spec.Resources == nil {
+		return
+	}
+
+	for _, resource := range api.Spec.Resources {
+		if resource.IsRef() {
+			*fields = append(*fields, resource.Ref.String())
+		}
+	}
+}
+
+func IndexTemplateConfigMaps(obj client.Object, fields *[]string) {
+	for _, ref := range template.ConfigMapRefs(obj) {
+		*fields = append(*fields, ref.String())
+	}
+}
+
+func IndexTemplateSecrets(obj client.Object, fields *[]string) {
+	for _, ref := range template.SecretRefs(obj) {
+		*fields = append(*fields, ref.String())
+	}
+}
+
+func IndexTemplateProviders(obj client.Object, fields *[]string) {
+	for _, ref := range template.ProviderRefs(obj) {
+		*fields = append(*fields, ref.String())
+	}
+}
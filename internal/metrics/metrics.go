@@ -0,0 +1,101 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics defines the operator metrics exposed along with the controller-runtime metrics.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "gko"
+
+var (
+	apimRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "apim_requests_total",
+		Help:      "Number of requests sent to the management API, by context, method, endpoint and status code.",
+	}, []string{"context", "method", "endpoint", "code"})
+
+	apimRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "apim_request_duration_seconds",
+		Help:      "Duration of the requests sent to the management API, by context, method and endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"context", "method", "endpoint"})
+
+	driftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "drift_detected_total",
+		Help:      "Number of times a resource was found to differ from its state in APIM, by kind and namespace.",
+	}, []string{"kind", "namespace", "corrected"})
+
+	keystoreEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tls_keystore_entries",
+		Help:      "Number of entries in the gateway keystore managed for ingresses, by namespace and keystore.",
+	}, []string{"namespace", "keystore"})
+
+	certificateExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "certificate_expiry_timestamp_seconds",
+		Help:      "Expiry date of the certificates added to the gateway keystore, by namespace, secret and common name.",
+	}, []string{"namespace", "secret", "common_name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		apimRequests,
+		apimRequestDuration,
+		driftDetected,
+		keystoreEntries,
+		certificateExpiry,
+	)
+}
+
+// ObserveAPIMRequest records a request sent to the management API. A status code
+// of zero means that no response was received.
+func ObserveAPIMRequest(context, method, endpoint string, statusCode int, duration time.Duration) {
+	code := "error"
+	if statusCode > 0 {
+		code = strconv.Itoa(statusCode)
+	}
+
+	apimRequests.WithLabelValues(context, method, endpoint, code).Inc()
+	apimRequestDuration.WithLabelValues(context, method, endpoint).Observe(duration.Seconds())
+}
+
+// RecordDrift records a drift detected between a resource and its state in APIM.
+func RecordDrift(kind, namespace string, corrected bool) {
+	driftDetected.WithLabelValues(kind, namespace, strconv.FormatBool(corrected)).Inc()
+}
+
+// SetKeystoreEntries records the number of entries of a gateway keystore.
+func SetKeystoreEntries(namespace, keystore string, entries int) {
+	keystoreEntries.WithLabelValues(namespace, keystore).Set(float64(entries))
+}
+
+// SetCertificateExpiry records the expiry date of a certificate added to a gateway keystore.
+func SetCertificateExpiry(namespace, secret, commonName string, notAfter time.Time) {
+	certificateExpiry.WithLabelValues(namespace, secret, commonName).Set(float64(notAfter.Unix()))
+}
+
+// DeleteCertificateExpiry stops reporting the expiry date of a certificate removed from a gateway keystore.
+func DeleteCertificateExpiry(namespace, secret, commonName string) {
+	certificateExpiry.DeleteLabelValues(namespace, secret, commonName)
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	pendingStatus = "Pending"
+	ingressKind   = "Ingress"
+)
+
+var (
+	managedResourcesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "managed_resources"),
+		"Number of resources managed by the operator, by kind, namespace and processing status.",
+		[]string{"kind", "namespace", "status"}, nil,
+	)
+
+	ingressApisDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ingress_apis"),
+		"Number of API definitions generated from ingresses, by namespace.",
+		[]string{"namespace"}, nil,
+	)
+)
+
+// resourceCollector counts the managed resources found in the cache of the manager each time metrics are scraped.
+type resourceCollector struct {
+	k8s client.Reader
+}
+
+// RegisterResourceCollector registers the metrics counting the resources managed by the operator,
+// read from k8s when metrics are scraped.
+func RegisterResourceCollector(k8s client.Reader) error {
+	return metrics.Registry.Register(&resourceCollector{k8s: k8s})
+}
+
+func (c *resourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedResourcesDesc
+	ch <- ingressApisDesc
+}
+
+func (c *resourceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	apis := &gio.ApiDefinitionList{}
+	if c.list(ctx, apis) {
+		counts := newCounts()
+		ingressApis := make(map[string]int)
+		for i := range apis.Items {
+			api := &apis.Items[i]
+			counts.add(api.Namespace, string(api.Status.Status))
+			if isOwnedByIngress(api) {
+				ingressApis[api.Namespace]++
+			}
+		}
+		counts.collect(ch, "ApiDefinition")
+		for ns, count := range ingressApis {
+			ch <- prometheus.MustNewConstMetric(ingressApisDesc, prometheus.GaugeValue, float64(count), ns)
+		}
+	}
+
+	apisV4 := &gio.ApiV4DefinitionList{}
+	if c.list(ctx, apisV4) {
+		counts := newCounts()
+		for i := range apisV4.Items {
+			counts.add(apisV4.Items[i].Namespace, string(apisV4.Items[i].Status.Status))
+		}
+		counts.collect(ch, "ApiV4Definition")
+	}
+
+	applications := &gio.ApplicationList{}
+	if c.list(ctx, applications) {
+		counts := newCounts()
+		for i := range applications.Items {
+			counts.add(applications.Items[i].Namespace, string(applications.Items[i].Status.Status))
+		}
+		counts.collect(ch, "Application")
+	}
+
+	subscriptions := &gio.SubscriptionList{}
+	if c.list(ctx, subscriptions) {
+		counts := newCounts()
+		for i := range subscriptions.Items {
+			counts.add(subscriptions.Items[i].Namespace, string(subscriptions.Items[i].Status.Status))
+		}
+		counts.collect(ch, "Subscription")
+	}
+}
+
+func (c *resourceCollector) list(ctx context.Context, list client.ObjectList) bool {
+	if err := c.k8s.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list resources for metrics", "list", fmt.Sprintf("%T", list))
+		return false
+	}
+	return true
+}
+
+// counts holds a number of resources by namespace and status.
+type counts map[[2]string]int
+
+func newCounts() counts {
+	return make(counts)
+}
+
+func (c counts) add(namespace, status string) {
+	if status == "" {
+		status = pendingStatus
+	}
+	c[[2]string{namespace, status}]++
+}
+
+func (c counts) collect(ch chan<- prometheus.Metric, kind string) {
+	for key, count := range c {
+		ch <- prometheus.MustNewConstMetric(managedResourcesDesc, prometheus.GaugeValue, float64(count), kind, key[0], key[1])
+	}
+}
+
+func isOwnedByIngress(obj metav1.Object) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.Kind == ingressKind {
+			return true
+		}
+	}
+	return false
+}
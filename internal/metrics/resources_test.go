@@ -0,0 +1,86 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Resource collector", func() {
+	newApi := func(
+		namespace, name string, status gio.ProcessingStatus, owners ...metav1.OwnerReference,
+	) *gio.ApiDefinition {
+		api := &gio.ApiDefinition{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, OwnerReferences: owners},
+		}
+		api.Status.Status = status
+		return api
+	}
+
+	gather := func(objects ...runtime.Object) map[string]float64 {
+		scheme := runtime.NewScheme()
+		Expect(gio.AddToScheme(scheme)).To(Succeed())
+		k8s := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+
+		registry := prometheus.NewRegistry()
+		Expect(registry.Register(&resourceCollector{k8s: k8s})).To(Succeed())
+
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		values := make(map[string]float64)
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				key := family.GetName()
+				for _, label := range metric.GetLabel() {
+					key += "," + label.GetName() + "=" + label.GetValue()
+				}
+				values[key] = metric.GetGauge().GetValue()
+			}
+		}
+		return values
+	}
+
+	It("Should count managed resources by kind, namespace and status", func() {
+		values := gather(
+			newApi("default", "api-1", gio.ProcessingStatusCompleted),
+			newApi("default", "api-2", gio.ProcessingStatusCompleted),
+			newApi("default", "api-3", gio.ProcessingStatusFailed),
+			newApi("apim", "api-4", ""),
+		)
+
+		Expect(values).To(Equal(map[string]float64{
+			"gko_managed_resources,kind=ApiDefinition,namespace=default,status=Completed": 2,
+			"gko_managed_resources,kind=ApiDefinition,namespace=default,status=Failed":    1,
+			"gko_managed_resources,kind=ApiDefinition,namespace=apim,status=Pending":      1,
+		}))
+	})
+
+	It("Should count API definitions generated from ingresses", func() {
+		ingress := metav1.OwnerReference{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "ingress"}
+		values := gather(
+			newApi("default", "api-1", gio.ProcessingStatusCompleted, ingress),
+			newApi("default", "api-2", gio.ProcessingStatusCompleted),
+		)
+
+		Expect(values).To(HaveKeyWithValue("gko_ingress_apis,namespace=default", 1.0))
+	})
+})
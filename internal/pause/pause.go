@@ -0,0 +1,100 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pause tells whether the reconciliation of a resource has been paused with the gravitee.io/paused
+// annotation, either on the resource itself, on its namespace or on the management contexts it depends on.
+package pause
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Check returns true if the reconciliation of the object is paused, in which case an event is recorded
+// and the Paused condition is written to the status of the object, unless conds is nil.
+// Otherwise, the Paused condition is removed from conds and written along with the rest of the status.
+func Check(
+	ctx context.Context, k8s client.Client, events *event.Recorder,
+	obj client.Object, conds *[]metav1.Condition, contextRefs ...refs.NamespacedName,
+) (bool, error) {
+	reason, err := Reason(ctx, k8s, obj, contextRefs...)
+	if err != nil {
+		return false, err
+	}
+
+	if reason == "" {
+		if conds != nil {
+			conditions.ClearPaused(conds)
+		}
+		return false, nil
+	}
+
+	log.FromContext(ctx).Info("Reconcile is paused", "reason", reason)
+	events.RecordPaused(obj, reason)
+
+	if conds == nil {
+		return true, nil
+	}
+
+	conditions.SetPaused(conds, obj.GetGeneration(), reason)
+	return true, k8s.Status().Update(ctx, obj)
+}
+
+// Reason returns why the reconciliation of the object is paused, or an empty string if it is not.
+// Namespaces are only looked up when the operator is watching the whole cluster.
+// Management contexts that can not be found are ignored, the reconcile reporting them as missing references.
+func Reason(
+	ctx context.Context, k8s client.Reader, obj client.Object, contextRefs ...refs.NamespacedName,
+) (string, error) {
+	if gio.IsPaused(obj) {
+		return fmt.Sprintf("%s is annotated with %s", obj.GetName(), keys.PausedAnnotation), nil
+	}
+
+	if env.Config.NS == "" {
+		ns := &corev1.Namespace{}
+		if err := k8s.Get(ctx, types.NamespacedName{Name: obj.GetNamespace()}, ns); client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		if gio.IsPaused(ns) {
+			return fmt.Sprintf("namespace %s is paused", ns.Name), nil
+		}
+	}
+
+	for _, ref := range contextRefs {
+		if ref.Namespace == "" {
+			ref.Namespace = obj.GetNamespace()
+		}
+		managementContext := &gio.ManagementContext{}
+		if err := k8s.Get(ctx, ref.ToK8sType(), managementContext); client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		if gio.IsPaused(managementContext) {
+			return fmt.Sprintf("management context %s is paused", ref), nil
+		}
+	}
+
+	return "", nil
+}
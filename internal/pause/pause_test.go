@@ -0,0 +1,108 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pause
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/event"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Pause", func() {
+	paused := map[string]string{keys.PausedAnnotation: "true"}
+
+	newMeta := func(namespace, name string, annotations map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations}
+	}
+
+	reason := func(api *gio.ApiDefinition, objects ...runtime.Object) string {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gio.AddToScheme(scheme)).To(Succeed())
+		k8s := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+		reason, err := Reason(context.Background(), k8s, api, api.ContextRefs()...)
+		Expect(err).ToNot(HaveOccurred())
+		return reason
+	}
+
+	newApi := func(annotations map[string]string) *gio.ApiDefinition {
+		api := &gio.ApiDefinition{ObjectMeta: newMeta("default", "api", annotations)}
+		api.Spec.Context = &refs.NamespacedName{Name: "dev-ctx"}
+		return api
+	}
+
+	It("Should not be paused without annotation", func() {
+		Expect(reason(
+			newApi(nil),
+			&corev1.Namespace{ObjectMeta: newMeta("", "default", nil)},
+			&gio.ManagementContext{ObjectMeta: newMeta("default", "dev-ctx", nil)},
+		)).To(BeEmpty())
+	})
+
+	It("Should not be paused when the namespace and the context are missing", func() {
+		Expect(reason(newApi(nil))).To(BeEmpty())
+	})
+
+	It("Should be paused by its own annotation", func() {
+		Expect(reason(newApi(paused))).To(Equal("api is annotated with gravitee.io/paused"))
+	})
+
+	It("Should be paused by its namespace", func() {
+		Expect(reason(
+			newApi(nil),
+			&corev1.Namespace{ObjectMeta: newMeta("", "default", paused)},
+		)).To(Equal("namespace default is paused"))
+	})
+
+	It("Should be paused by its management context", func() {
+		Expect(reason(
+			newApi(nil),
+			&gio.ManagementContext{ObjectMeta: newMeta("default", "dev-ctx", paused)},
+		)).To(Equal("management context default/dev-ctx is paused"))
+	})
+
+	It("Should report the pause in the status and events of a paused resource", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gio.AddToScheme(scheme)).To(Succeed())
+		api := newApi(paused)
+		k8s := fake.NewClientBuilder().WithScheme(scheme).WithObjects(api).WithStatusSubresource(api).Build()
+		recorder := record.NewFakeRecorder(1)
+
+		isPaused, err := Check(context.Background(), k8s, event.NewRecorder(recorder), api, &api.Status.Conditions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isPaused).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("Reconcile paused")))
+
+		updated := &gio.ApiDefinition{}
+		Expect(k8s.Get(context.Background(), client.ObjectKeyFromObject(api), updated)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, conditions.Paused)).To(BeTrue())
+	})
+})
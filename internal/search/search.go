@@ -16,8 +16,11 @@ package search
 
 import (
 	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
 	"golang.org/x/net/context"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/fields"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -45,3 +48,27 @@ func (s *Type) FindByFieldReferencing(
 
 	return s.k8s.List(s.ctx, result, filter)
 }
+
+// IsReferencedByTemplates tells if any resource supporting templating is using
+// the config map or the secret designated by ref in its templates.
+func (s *Type) IsReferencedByTemplates(field indexer.IndexField, ref refs.NamespacedName) (bool, error) {
+	lists := []client.ObjectList{
+		&v1alpha1.ApiDefinitionList{},
+		&v1alpha1.ApiV4DefinitionList{},
+		&v1alpha1.ApplicationList{},
+		&v1alpha1.ApiResourceList{},
+		&v1alpha1.ManagementContextList{},
+		&netv1.IngressList{},
+	}
+
+	for _, list := range lists {
+		if err := s.FindByFieldReferencing(field, ref, list); err != nil {
+			return false, err
+		}
+		if meta.LenList(list) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/api/base"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Search", func() {
+	newSearch := func(objects ...client.Object) *Type {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gio.AddToScheme(scheme)).To(Succeed())
+
+		secretIndexer := indexer.NewIndexer(indexer.TemplateSecretField, indexer.IndexTemplateSecrets)
+		builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...)
+		for _, obj := range []client.Object{
+			&gio.ApiDefinition{},
+			&gio.ApiV4Definition{},
+			&gio.Application{},
+			&gio.ApiResource{},
+			&gio.ManagementContext{},
+			&netv1.Ingress{},
+		} {
+			builder = builder.WithIndex(obj, secretIndexer.Field, secretIndexer.Func)
+		}
+
+		return New(context.Background(), builder.Build())
+	}
+
+	newResource := func(name string) *gio.ApiResource {
+		return &gio.ApiResource{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cache"},
+			Spec:       gio.ApiResourceSpec{Resource: &base.Resource{Name: name}},
+		}
+	}
+
+	It("Should find a secret used in the templates of a resource", func() {
+		search := newSearch(newResource(`[[ secret "redis/name" ]]`))
+
+		referenced, err := search.IsReferencedByTemplates(
+			indexer.TemplateSecretField, refs.NewNamespacedName("default", "redis"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(referenced).To(BeTrue())
+	})
+
+	It("Should not find a secret used in another namespace", func() {
+		search := newSearch(newResource(`[[ secret "redis/name" ]]`))
+
+		referenced, err := search.IsReferencedByTemplates(
+			indexer.TemplateSecretField, refs.NewNamespacedName("other", "redis"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(referenced).To(BeFalse())
+	})
+
+	It("Should not find a secret that is not used anymore", func() {
+		search := newSearch(newResource("cache"))
+
+		referenced, err := search.IsReferencedByTemplates(
+			indexer.TemplateSecretField, refs.NewNamespacedName("default", "redis"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(referenced).To(BeFalse())
+	})
+})
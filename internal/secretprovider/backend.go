@@ -0,0 +1,136 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretprovider reads the values used in templates from the backends
+// declared by SecretProvider resources, caching them in memory only.
+package secretprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+)
+
+// Values are read from a backend at a given path, and can be cached for ttl.
+// A zero ttl means that the backend did not return any lease.
+type Values struct {
+	Data map[string]string
+	TTL  time.Duration
+}
+
+type backend interface {
+	Read(ctx context.Context, path string) (*Values, error)
+}
+
+func newBackend(provider *gio.SecretProvider) (backend, error) {
+	client := &http.Client{
+		Timeout: time.Duration(env.Config.HttpClientTimeout) * time.Second,
+		// Tokens must not be sent to a host that is not allowed by following a redirect
+		CheckRedirect: func(req *http.Request, _ []*http.Request) error {
+			return checkURL(req.URL)
+		},
+	}
+
+	switch {
+	case provider.Spec.Vault != nil:
+		if err := CheckURL(provider.Spec.Vault.Address); err != nil {
+			return nil, err
+		}
+		return &vault{spec: provider.Spec.Vault, client: client, tokenPath: env.Config.VaultTokenPath}, nil
+	case provider.Spec.HTTP != nil:
+		if err := CheckURL(provider.Spec.HTTP.URL); err != nil {
+			return nil, err
+		}
+		return &httpJSON{spec: provider.Spec.HTTP, client: client, tokenPath: env.Config.HTTPTokenPath}, nil
+	default:
+		return nil, fmt.Errorf("secret provider %s has no backend", provider.Name)
+	}
+}
+
+// CheckURL returns an error unless values can be read from the given URL. Backends being sent the tokens
+// of the operator, they can only be reached at the hosts allowed in the configuration of the operator.
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%s is not an absolute URL", rawURL)
+	}
+	return checkURL(u)
+}
+
+func checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %s, expected http or https", u.Scheme)
+	}
+	if u.User != nil {
+		return fmt.Errorf("URL of host %s must not hold credentials", u.Host)
+	}
+	for _, host := range env.Config.SecretProviderHosts {
+		if strings.EqualFold(host, u.Host) || strings.EqualFold(host, u.Hostname()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %s is not allowed for secret providers", u.Host)
+}
+
+// readToken reads a token from a file, tokens being rotated they are never cached.
+func readToken(path string) (string, error) {
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token: %w", err)
+	}
+	return string(token), nil
+}
+
+// toStrings converts the values of a JSON object to strings, values which are not strings being JSON encoded.
+func toStrings(object map[string]interface{}) (map[string]string, error) {
+	data := make(map[string]string, len(object))
+	for key, value := range object {
+		if s, ok := value.(string); ok {
+			data[key] = s
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		data[key] = string(encoded)
+	}
+	return data, nil
+}
+
+func decode(res *http.Response, target any) error {
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return &StatusError{URL: res.Request.URL.String(), Status: res.StatusCode}
+	}
+	return json.NewDecoder(res.Body).Decode(target)
+}
+
+// StatusError is returned when a backend answers with an error status.
+type StatusError struct {
+	URL    string
+	Status int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d from %s", e.Status, e.URL)
+}
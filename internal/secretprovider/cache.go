@@ -0,0 +1,219 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretprovider
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const defaultRefreshInterval = 300 * time.Second
+
+type entry struct {
+	provider refs.NamespacedName
+	path     string
+	values   map[string]string
+	expires  time.Time
+}
+
+type backendEntry struct {
+	generation int64
+	backend    backend
+}
+
+var cache = struct {
+	sync.Mutex
+	backends  map[string]backendEntry
+	entries   map[string]*entry
+	listeners []chan event.GenericEvent
+}{backends: make(map[string]backendEntry), entries: make(map[string]*entry)}
+
+// Lookup returns the value of key at path in the backend of the provider,
+// values being read from the cache until they expire. Missing keys resolve to an empty value.
+func Lookup(ctx context.Context, k8s client.Reader, ref refs.NamespacedName, path, key string) (string, error) {
+	provider := &gio.SecretProvider{}
+	if err := k8s.Get(ctx, ref.ToK8sType(), provider); err != nil {
+		return "", err
+	}
+
+	// Looking up the backend first drops the values cached for a previous generation of the provider
+	if _, err := backendOf(provider); err != nil {
+		return "", err
+	}
+
+	if values, ok := cached(ref, path); ok {
+		return values[key], nil
+	}
+
+	values, err := read(ctx, provider, path)
+	if err != nil {
+		return "", err
+	}
+
+	return values[key], nil
+}
+
+// Changes returns a source of events sent with the provider each time values read from it have changed.
+func Changes() source.Source {
+	return &source.Channel{Source: subscribe()}
+}
+
+// How many changes can be pending for a listener before the next ones are dropped.
+const changesSize = 64
+
+func subscribe() chan event.GenericEvent {
+	cache.Lock()
+	defer cache.Unlock()
+
+	ch := make(chan event.GenericEvent, changesSize)
+	cache.listeners = append(cache.listeners, ch)
+	return ch
+}
+
+// Listeners are only read once their controller is started, which never happens on a replica
+// that is not the leader, changes are therefore dropped rather than waited for when they are full.
+func notify(ref refs.NamespacedName) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	for _, ch := range cache.listeners {
+		provider := &gio.SecretProvider{ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name}}
+		select {
+		case ch <- event.GenericEvent{Object: provider}:
+		default:
+		}
+	}
+}
+
+func cached(ref refs.NamespacedName, path string) (map[string]string, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	e, ok := cache.entries[entryKey(ref, path)]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.values, true
+}
+
+// read reads the values at path from the backend of the provider and caches them.
+func read(ctx context.Context, provider *gio.SecretProvider, path string) (map[string]string, error) {
+	b, err := backendOf(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := b.Read(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := values.TTL
+	if ttl == 0 {
+		ttl = refreshInterval(provider)
+	}
+
+	ref := refs.NewNamespacedName(provider.Namespace, provider.Name)
+
+	cache.Lock()
+	defer cache.Unlock()
+	cache.entries[entryKey(ref, path)] = &entry{
+		provider: ref, path: path, values: values.Data, expires: time.Now().Add(ttl),
+	}
+
+	return values.Data, nil
+}
+
+// backendOf returns the backend of the provider, a new one being created when the provider changes.
+// Values read from the backend of a previous generation, which may differ entirely, are dropped.
+func backendOf(provider *gio.SecretProvider) (backend, error) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	key := refs.NewNamespacedName(provider.Namespace, provider.Name).String()
+	if b, ok := cache.backends[key]; ok && b.generation == provider.Generation {
+		return b.backend, nil
+	}
+
+	delete(cache.backends, key)
+	for entryKey, e := range cache.entries {
+		if e.provider.String() == key {
+			delete(cache.entries, entryKey)
+		}
+	}
+
+	b, err := newBackend(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.backends[key] = backendEntry{generation: provider.Generation, backend: b}
+	return b, nil
+}
+
+func expired() []entry {
+	cache.Lock()
+	defer cache.Unlock()
+
+	now := time.Now()
+	entries := make([]entry, 0)
+	for _, e := range cache.entries {
+		if now.After(e.expires) {
+			entries = append(entries, *e)
+		}
+	}
+	return entries
+}
+
+// postpone keeps the values of an entry that could not be refreshed until the next refresh.
+func postpone(e entry, delay time.Duration) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	if current, ok := cache.entries[entryKey(e.provider, e.path)]; ok {
+		current.expires = time.Now().Add(delay)
+	}
+}
+
+func forget(e entry) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	delete(cache.entries, entryKey(e.provider, e.path))
+}
+
+func changed(e entry, values map[string]string) bool {
+	return !maps.Equal(e.values, values)
+}
+
+func refreshInterval(provider *gio.SecretProvider) time.Duration {
+	if provider.Spec.RefreshInterval <= 0 {
+		return defaultRefreshInterval
+	}
+	return time.Duration(provider.Spec.RefreshInterval) * time.Second
+}
+
+func entryKey(ref refs.NamespacedName, path string) string {
+	return ref.String() + "|" + path
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretprovider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+)
+
+const maxAgeDirective = "max-age="
+
+// httpJSON reads values from a JSON object returned by an HTTP endpoint,
+// the max-age directive of its Cache-Control header being used as lease.
+type httpJSON struct {
+	spec      *gio.HTTPSecretProvider
+	client    *http.Client
+	tokenPath string
+}
+
+func (h *httpJSON) Read(ctx context.Context, path string) (*Values, error) {
+	url := strings.TrimSuffix(h.spec.URL, "/") + "/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range h.spec.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if h.tokenPath != "" {
+		token, tokenErr := readToken(h.tokenPath)
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(token))
+	}
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	object := make(map[string]interface{})
+	if err = decode(res, &object); err != nil {
+		return nil, err
+	}
+
+	data, err := toStrings(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Values{Data: data, TTL: maxAge(res.Header.Get("Cache-Control"))}, nil
+}
+
+func maxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, maxAgeDirective)); err == nil &&
+			strings.HasPrefix(directive, maxAgeDirective) {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
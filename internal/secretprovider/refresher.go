@@ -0,0 +1,107 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretprovider
+
+import (
+	"context"
+	"time"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	kErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const refreshTick = 10 * time.Second
+
+var logger = log.Log.WithName("secretprovider")
+
+// ReferencedFunc tells if a provider is still used in the templates of some resources.
+type ReferencedFunc = func(ctx context.Context, ref refs.NamespacedName) (bool, error)
+
+// Refresher reads again the cached values once they expire, resources using them being
+// reconciled when they change. Values of providers that are not used anymore are forgotten.
+type Refresher struct {
+	k8s        client.Reader
+	referenced ReferencedFunc
+	tick       time.Duration
+}
+
+func NewRefresher(k8s client.Reader, referenced ReferencedFunc) *Refresher {
+	return &Refresher{k8s: k8s, referenced: referenced, tick: refreshTick}
+}
+
+// NeedLeaderElection returns true, resources being only reconciled by the leader.
+func (r *Refresher) NeedLeaderElection() bool {
+	return true
+}
+
+// Start refreshes expired values until ctx is done.
+func (r *Refresher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.Refresh(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Refresh refreshes all the expired values.
+func (r *Refresher) Refresh(ctx context.Context) {
+	for _, e := range expired() {
+		if err := r.refresh(ctx, e); err != nil {
+			logger.Error(err, "unable to refresh secret provider values", "provider", e.provider.String(), "path", e.path)
+			postpone(e, r.tick)
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context, e entry) error {
+	provider := &gio.SecretProvider{}
+	if err := r.k8s.Get(ctx, e.provider.ToK8sType(), provider); kErrors.IsNotFound(err) {
+		forget(e)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	referenced, err := r.referenced(ctx, e.provider)
+	if err != nil {
+		return err
+	}
+
+	if !referenced {
+		forget(e)
+		return nil
+	}
+
+	values, err := read(ctx, provider, e.path)
+	if err != nil {
+		return err
+	}
+
+	if changed(e, values) {
+		logger.Info("secret provider values changed", "provider", e.provider.String(), "path", e.path)
+		notify(e.provider)
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gravitee-io/gravitee-kubernetes-operator/api/model/refs"
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("Secret provider", func() {
+	ref := refs.NewNamespacedName("default", "backend")
+
+	var server *httptest.Server
+	var reads atomic.Int32
+	var password atomic.Value
+	var hosts []string
+	var vaultTokenPath, httpTokenPath string
+
+	newClient := func(spec gio.SecretProviderSpec) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(gio.AddToScheme(scheme)).To(Succeed())
+		provider := &gio.SecretProvider{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name, Generation: 1},
+			Spec:       spec,
+		}
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(provider).Build()
+	}
+
+	BeforeEach(func() {
+		cache.Lock()
+		cache.backends = make(map[string]backendEntry)
+		cache.entries = make(map[string]*entry)
+		cache.Unlock()
+
+		reads.Store(0)
+		password.Store("s3cr3t")
+
+		hosts = env.Config.SecretProviderHosts
+		vaultTokenPath, httpTokenPath = env.Config.VaultTokenPath, env.Config.HTTPTokenPath
+		tokenPath := filepath.Join(GinkgoT().TempDir(), "token")
+		Expect(os.WriteFile(tokenPath, []byte("service-account-jwt\n"), 0o600)).To(Succeed())
+		env.Config.SecretProviderHosts = []string{"127.0.0.1"}
+		env.Config.VaultTokenPath, env.Config.HTTPTokenPath = tokenPath, tokenPath
+
+		// A stand-in for Vault and for an HTTP secret backend
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/v1/auth/kubernetes/login":
+				login := map[string]string{}
+				Expect(json.NewDecoder(r.Body).Decode(&login)).To(Succeed())
+				Expect(login).To(Equal(map[string]string{"role": "gko", "jwt": "service-account-jwt"}))
+				Expect(r.Header.Get(vaultNamespaceHeader)).To(Equal("team-a"))
+				_, _ = w.Write([]byte(`{"auth":{"client_token":"vault-token","lease_duration":3600}}`))
+			case "/v1/secret/data/apps/db":
+				reads.Add(1)
+				Expect(r.Header.Get(vaultTokenHeader)).To(Equal("vault-token"))
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{"data": map[string]interface{}{"password": password.Load()}},
+				})
+			case "/redirect/apps/db":
+				http.Redirect(w, r, "http://localhost/secrets/apps/db", http.StatusFound)
+			case "/secrets/apps/db":
+				reads.Add(1)
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer service-account-jwt"))
+				Expect(r.Header.Get("X-Team")).To(Equal("team-a"))
+				w.Header().Set("Cache-Control", "private, max-age=60")
+				_, _ = w.Write([]byte(`{"password":"` + password.Load().(string) + `","port":5432}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Config.SecretProviderHosts = hosts
+		env.Config.VaultTokenPath, env.Config.HTTPTokenPath = vaultTokenPath, httpTokenPath
+	})
+
+	vaultSpec := func() gio.SecretProviderSpec {
+		return gio.SecretProviderSpec{
+			Vault: &gio.VaultSecretProvider{
+				Address:   server.URL,
+				Namespace: "team-a",
+				Mount:     "secret",
+				Auth:      gio.VaultKubernetesAuth{Role: "gko", Mount: "kubernetes"},
+			},
+			RefreshInterval: 300,
+		}
+	}
+
+	It("Should read values from vault and cache them", func() {
+		k8s := newClient(vaultSpec())
+
+		for i := 0; i < 2; i++ {
+			value, err := Lookup(context.Background(), k8s, ref, "apps/db", "password")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("s3cr3t"))
+		}
+		Expect(reads.Load()).To(Equal(int32(1)))
+	})
+
+	It("Should read values from an HTTP endpoint using its max age as lease", func() {
+		k8s := newClient(gio.SecretProviderSpec{
+			HTTP: &gio.HTTPSecretProvider{
+				URL:     server.URL + "/secrets/",
+				Headers: map[string]string{"X-Team": "team-a"},
+			},
+		})
+
+		value, err := Lookup(context.Background(), k8s, ref, "apps/db", "port")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("5432"))
+
+		Expect(cache.entries[entryKey(ref, "apps/db")].expires).To(
+			BeTemporally("~", time.Now().Add(60*time.Second), 5*time.Second),
+		)
+	})
+
+	It("Should fail on unknown paths", func() {
+		k8s := newClient(vaultSpec())
+
+		_, err := Lookup(context.Background(), k8s, ref, "apps/unknown", "password")
+		Expect(err).To(MatchError(ContainSubstring("unexpected status 404")))
+	})
+
+	It("Should notify changes when refreshing expired values", func() {
+		k8s := newClient(vaultSpec())
+		changes := subscribe()
+
+		_, err := Lookup(context.Background(), k8s, ref, "apps/db", "password")
+		Expect(err).ToNot(HaveOccurred())
+
+		password.Store("rotated")
+		cache.entries[entryKey(ref, "apps/db")].expires = time.Now().Add(-time.Second)
+
+		referenced := func(context.Context, refs.NamespacedName) (bool, error) { return true, nil }
+		NewRefresher(k8s, referenced).Refresh(context.Background())
+
+		var change event.GenericEvent
+		Eventually(changes).Should(Receive(&change))
+		Expect(change.Object.GetName()).To(Equal(ref.Name))
+
+		value, err := Lookup(context.Background(), k8s, ref, "apps/db", "password")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("rotated"))
+	})
+
+	It("Should not wait for listeners that are not read", func() {
+		changes := subscribe()
+		for i := 0; i <= changesSize; i++ {
+			notify(ref)
+		}
+		Expect(changes).To(HaveLen(changesSize))
+	})
+
+	It("Should forget values that are not used anymore", func() {
+		k8s := newClient(vaultSpec())
+
+		_, err := Lookup(context.Background(), k8s, ref, "apps/db", "password")
+		Expect(err).ToNot(HaveOccurred())
+		cache.entries[entryKey(ref, "apps/db")].expires = time.Now().Add(-time.Second)
+
+		unreferenced := func(context.Context, refs.NamespacedName) (bool, error) { return false, nil }
+		NewRefresher(k8s, unreferenced).Refresh(context.Background())
+
+		Expect(cache.entries).To(BeEmpty())
+		Expect(reads.Load()).To(Equal(int32(1)))
+	})
+
+	It("Should not send tokens to hosts that are not allowed", func() {
+		env.Config.SecretProviderHosts = []string{"vault.vault.svc"}
+		k8s := newClient(vaultSpec())
+
+		_, err := Lookup(context.Background(), k8s, ref, "apps/db", "password")
+		Expect(err).To(MatchError(ContainSubstring("is not allowed for secret providers")))
+		Expect(reads.Load()).To(Equal(int32(0)))
+	})
+
+	It("Should not follow redirects to hosts that are not allowed", func() {
+		k8s := newClient(gio.SecretProviderSpec{
+			HTTP: &gio.HTTPSecretProvider{URL: server.URL + "/redirect/"},
+		})
+
+		_, err := Lookup(context.Background(), k8s, ref, "apps/db", "password")
+		Expect(err).To(MatchError(ContainSubstring("host localhost is not allowed for secret providers")))
+		Expect(reads.Load()).To(Equal(int32(0)))
+	})
+
+	It("Should drop the values read with a previous generation of the provider", func() {
+		k8s := newClient(vaultSpec())
+
+		_, err := Lookup(context.Background(), k8s, ref, "apps/db", "password")
+		Expect(err).ToNot(HaveOccurred())
+
+		provider := &gio.SecretProvider{}
+		Expect(k8s.Get(context.Background(), ref.ToK8sType(), provider)).To(Succeed())
+		provider.Generation = 2
+		provider.Spec = gio.SecretProviderSpec{
+			HTTP: &gio.HTTPSecretProvider{URL: server.URL + "/secrets/", Headers: map[string]string{"X-Team": "team-a"}},
+		}
+		Expect(k8s.Update(context.Background(), provider)).To(Succeed())
+
+		value, err := Lookup(context.Background(), k8s, ref, "apps/db", "port")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("5432"))
+		Expect(reads.Load()).To(Equal(int32(2)))
+	})
+})
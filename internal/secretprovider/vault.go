@@ -0,0 +1,164 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+)
+
+const (
+	vaultTokenHeader     = "X-Vault-Token"
+	vaultNamespaceHeader = "X-Vault-Namespace"
+)
+
+// vault reads values from the KV v2 secrets engine, logging in with the Kubernetes auth method.
+type vault struct {
+	sync.Mutex
+	spec      *gio.VaultSecretProvider
+	client    *http.Client
+	tokenPath string
+	token     string
+	expires   time.Time
+}
+
+type vaultLogin struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+type vaultSecret struct {
+	LeaseDuration int `json:"lease_duration"`
+	Data          struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func (v *vault) Read(ctx context.Context, path string) (*Values, error) {
+	secret, err := v.read(ctx, path)
+
+	// The token may have been revoked before the end of its lease
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Status == http.StatusForbidden {
+		v.forgetToken()
+		secret, err = v.read(ctx, path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := toStrings(secret.Data.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Values{Data: data, TTL: time.Duration(secret.LeaseDuration) * time.Second}, nil
+}
+
+func (v *vault) read(ctx context.Context, path string) (*vaultSecret, error) {
+	token, err := v.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := v.newRequest(ctx, http.MethodGet, v.url(v.spec.Mount, "data", path), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(vaultTokenHeader, token)
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &vaultSecret{}
+	return secret, decode(res, secret)
+}
+
+// login returns the current token, logging in again once its lease has expired.
+func (v *vault) login(ctx context.Context) (string, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	if v.token != "" && time.Now().Before(v.expires) {
+		return v.token, nil
+	}
+
+	jwt, err := readToken(v.tokenPath)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{"role": v.spec.Auth.Role, "jwt": strings.TrimSpace(jwt)})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := v.newRequest(ctx, http.MethodPost, v.url("auth", v.spec.Auth.Mount, "login"), body)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	login := &vaultLogin{}
+	if err = decode(res, login); err != nil {
+		return "", err
+	}
+
+	v.token = login.Auth.ClientToken
+	v.expires = time.Now().Add(time.Duration(login.Auth.LeaseDuration) * time.Second)
+	return v.token, nil
+}
+
+func (v *vault) forgetToken() {
+	v.Lock()
+	defer v.Unlock()
+	v.token = ""
+}
+
+func (v *vault) newRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if v.spec.Namespace != "" {
+		req.Header.Set(vaultNamespaceHeader, v.spec.Namespace)
+	}
+	return req, nil
+}
+
+func (v *vault) url(segments ...string) string {
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		path = append(path, strings.Trim(segment, "/"))
+	}
+	return strings.TrimSuffix(v.spec.Address, "/") + "/v1/" + strings.Join(path, "/")
+}
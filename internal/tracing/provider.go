@@ -0,0 +1,75 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Provider exports the spans of the operator to an OpenTelemetry collector.
+// It is added to the manager to flush pending spans when the operator stops.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewProvider returns a provider exporting spans using OTLP over HTTP.
+// The exporter and the sampler are configured with the standard OTEL_* variables.
+func NewProvider(ctx context.Context, serviceName string) (*Provider, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		provider: sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)),
+	}, nil
+}
+
+// Register records spans with the provider and propagates
+// the trace context in W3C traceparent headers.
+func Register(p *Provider) {
+	otel.SetTracerProvider(p.provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func (p *Provider) NeedLeaderElection() bool {
+	return false
+}
+
+// Start waits for ctx to be done, then flushes pending spans and stops the exporter.
+func (p *Provider) Start(ctx context.Context) error {
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return p.provider.Shutdown(shutdownCtx)
+}
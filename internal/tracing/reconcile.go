@@ -0,0 +1,38 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StartReconcile starts the span of the reconcile of an object of the given kind.
+func StartReconcile(ctx context.Context, kind string, name types.NamespacedName) (context.Context, *Span) {
+	return Start(
+		ctx,
+		"Reconcile "+kind,
+		String("k8s.kind", kind),
+		String("k8s.namespace", name.Namespace),
+		String("k8s.name", name.Name),
+	)
+}
+
+// SetGeneration adds the generation of the reconciled object to the span carried by ctx.
+func SetGeneration(ctx context.Context, generation int64) {
+	trace.SpanFromContext(ctx).SetAttributes(Int64("k8s.generation", generation))
+}
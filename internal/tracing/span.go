@@ -0,0 +1,57 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing records the spans of reconciles and of the requests they send,
+// exporting them to an OpenTelemetry collector when a provider is registered.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const scopeName = "github.com/gravitee-io/gravitee-kubernetes-operator"
+
+// Span is an operation of a trace. Spans started while no provider
+// is registered are not recorded.
+type Span struct {
+	span trace.Span
+}
+
+func String(key, value string) attribute.KeyValue {
+	return attribute.String(key, value)
+}
+
+func Int64(key string, value int64) attribute.KeyValue {
+	return attribute.Int64(key, value)
+}
+
+// Start starts a span as a child of the span of ctx, if any.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, *Span) {
+	ctx, span := otel.Tracer(scopeName).Start(ctx, name, trace.WithAttributes(attributes...))
+	return ctx, &Span{span: span}
+}
+
+// End ends the span, marking it as failed if err is not nil.
+func (s *Span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
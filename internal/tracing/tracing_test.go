@@ -0,0 +1,130 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Tracing", func() {
+	var recorder *tracetest.InMemoryExporter
+
+	BeforeEach(func() {
+		recorder = tracetest.NewInMemoryExporter()
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	register := func() {
+		Register(&Provider{provider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(recorder))})
+	}
+
+	inject := func(ctx context.Context) string {
+		header := http.Header{}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+		return header.Get("traceparent")
+	}
+
+	name := types.NamespacedName{Namespace: "default", Name: "api"}
+
+	It("Should not record spans when no provider is registered", func() {
+		ctx, span := Start(context.Background(), "span")
+		Expect(trace.SpanFromContext(ctx).IsRecording()).To(BeFalse())
+		Expect(inject(ctx)).To(BeEmpty())
+		span.End(errors.New("ignored"))
+	})
+
+	It("Should propagate the trace context of the span", func() {
+		register()
+
+		ctx, _ := StartReconcile(context.Background(), "ApiDefinition", name)
+		parent := trace.SpanContextFromContext(ctx)
+		ctx, _ = Start(ctx, "GET /apis")
+		child := trace.SpanContextFromContext(ctx)
+
+		traceParent := inject(ctx)
+		Expect(traceParent).To(MatchRegexp("^00-[0-9a-f]{32}-[0-9a-f]{16}-01$"))
+		Expect(traceParent).To(Equal("00-" + parent.TraceID().String() + "-" + child.SpanID().String() + "-01"))
+		Expect(child.TraceID()).To(Equal(parent.TraceID()))
+	})
+
+	It("Should record ended spans", func() {
+		register()
+
+		ctx, parent := StartReconcile(context.Background(), "ApiDefinition", name)
+		SetGeneration(ctx, 3)
+		_, child := Start(ctx, "GET /apis")
+		child.End(errors.New("500 Internal Server Error"))
+		parent.End(nil)
+
+		spans := recorder.GetSpans()
+		Expect(spans).To(HaveLen(2))
+
+		Expect(spans[0].Name).To(Equal("GET /apis"))
+		Expect(spans[0].Parent.SpanID()).To(Equal(spans[1].SpanContext.SpanID()))
+		Expect(spans[0].Status.Code).To(Equal(codes.Error))
+		Expect(spans[0].Status.Description).To(Equal("500 Internal Server Error"))
+
+		Expect(spans[1].Name).To(Equal("Reconcile ApiDefinition"))
+		Expect(spans[1].Parent.IsValid()).To(BeFalse())
+		Expect(spans[1].Status.Code).To(Equal(codes.Unset))
+		Expect(spans[1].Attributes).To(ContainElement(attribute.Int64("k8s.generation", 3)))
+	})
+
+	It("Should export pending spans to the collector when stopped", func() {
+		paths := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.Path
+		}))
+		defer server.Close()
+
+		Expect(os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)).To(Succeed())
+		defer os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+
+		provider, err := NewProvider(context.Background(), "gko")
+		Expect(err).ToNot(HaveOccurred())
+		Register(provider)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- provider.Start(ctx) }()
+
+		_, span := StartReconcile(ctx, "ApiDefinition", name)
+		span.End(nil)
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(paths).To(Receive(Equal("/v1/traces")))
+	})
+})
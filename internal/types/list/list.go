@@ -27,6 +27,8 @@ func OfType(obj interface{}) (client.ObjectList, error) {
 	switch obj.(type) {
 	case *v1alpha1.ApiDefinitionList:
 		return &v1alpha1.ApiDefinitionList{}, nil
+	case *v1alpha1.ApiV4DefinitionList:
+		return &v1alpha1.ApiV4DefinitionList{}, nil
 	case *v1alpha1.ManagementContextList:
 		return &v1alpha1.ManagementContextList{}, nil
 	case *netv1.IngressList:
@@ -35,6 +37,10 @@ func OfType(obj interface{}) (client.ObjectList, error) {
 		return &v1.SecretList{}, nil
 	case *v1alpha1.ApplicationList:
 		return &v1alpha1.ApplicationList{}, nil
+	case *v1alpha1.SubscriptionList:
+		return &v1alpha1.SubscriptionList{}, nil
+	case *v1alpha1.ApiResourceList:
+		return &v1alpha1.ApiResourceList{}, nil
 	default:
 		return nil, fmt.Errorf("unknown type %T", obj)
 	}
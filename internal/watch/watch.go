@@ -24,13 +24,16 @@ import (
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/search"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/types/list"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -39,6 +42,9 @@ type Interface interface {
 	WatchResources() *handler.Funcs
 	WatchApiTemplate() *handler.Funcs
 	WatchTLSSecret() *handler.Funcs
+	WatchSubscriptionRefs(index indexer.IndexField) *handler.Funcs
+	WatchNamespaces() *handler.Funcs
+	WatchTemplateRefs(index indexer.IndexField) *handler.Funcs
 }
 
 type UpdateFunc = func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface)
@@ -71,23 +77,26 @@ func (w *Type) WatchContexts(index indexer.IndexField) *handler.Funcs {
 }
 
 func ContextSecrets() *handler.Funcs {
+	queueSecret := func(ref refs.NamespacedName, namespace string, q workqueue.RateLimitingInterface) {
+		if ref.Namespace != "" {
+			namespace = ref.Namespace
+		}
+		q.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      ref.Name,
+				Namespace: namespace,
+			},
+		})
+	}
+
 	queueSecrets := func(obj client.Object, q workqueue.RateLimitingInterface) {
 		ctx, ok := obj.(*v1alpha1.ManagementContext)
 		if !ok {
 			return
 		}
 
-		if ctx.Spec.HasSecretRef() {
-			ns := ctx.Spec.Auth.SecretRef.Namespace
-			if ns == "" {
-				ns = ctx.Namespace
-			}
-			q.Add(reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Name:      ctx.Spec.Auth.SecretRef.Name,
-					Namespace: ns,
-				},
-			})
+		for _, ref := range ctx.Spec.SecretRefs() {
+			queueSecret(ref, ctx.Namespace, q)
 		}
 	}
 
@@ -127,6 +136,85 @@ func (w *Type) WatchTLSSecret() *handler.Funcs {
 	}
 }
 
+// WatchSubscriptionRefs can be used to trigger a reconciliation when an API definition or an application
+// is updated on subscriptions that are referencing it.
+func (w *Type) WatchSubscriptionRefs(index indexer.IndexField) *handler.Funcs {
+	return &handler.Funcs{
+		UpdateFunc: w.UpdateFromLookup(index),
+		CreateFunc: w.CreateFromLookup(index),
+	}
+}
+
+// WatchNamespaces can be used to trigger a reconciliation of all the resources of a namespace
+// when the namespace is paused or resumed with the gravitee.io/paused annotation.
+func (w *Type) WatchNamespaces() *handler.Funcs {
+	return &handler.Funcs{
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if v1alpha1.IsPaused(e.ObjectOld) != v1alpha1.IsPaused(e.ObjectNew) {
+				w.queueInNamespace(e.ObjectNew.GetName(), q)
+			}
+		},
+	}
+}
+
+// WatchTemplateRefs can be used to trigger a reconciliation when a config map, a secret or
+// a secret provider is updated on resources that are using it in their templates. API definitions
+// are also reconciled when the config map or the secret is used in the templates of one of the
+// API resources they reference.
+func (w *Type) WatchTemplateRefs(index indexer.IndexField) *handler.Funcs {
+	queue := func(obj client.Object, q workqueue.RateLimitingInterface) {
+		ref := refs.NewNamespacedName(obj.GetNamespace(), obj.GetName())
+		w.queueByFieldReferencing(index, ref, q)
+		w.queueByResourcesReferencing(index, ref, q)
+	}
+
+	return &handler.Funcs{
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			queue(e.ObjectNew, q)
+		},
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			queue(e.Object, q)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+			queue(e.Object, q)
+		},
+	}
+}
+
+// TemplateSources can be used to trigger a reconciliation of the config maps or secrets that were
+// used in the templates of a resource when this resource is updated or deleted, so that they can be released.
+func TemplateSources(refsOf func(runtime.Object) []refs.NamespacedName) *handler.Funcs {
+	queue := func(obj runtime.Object, q workqueue.RateLimitingInterface) {
+		for _, ref := range refsOf(obj) {
+			q.Add(reconcile.Request{NamespacedName: ref.ToK8sType()})
+		}
+	}
+
+	return &handler.Funcs{
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			queue(e.ObjectOld, q)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			queue(e.Object, q)
+		},
+	}
+}
+
+// TemplateSourceChanged lets through the updates of config maps and secrets,
+// that are never filtered out by generation as they do not have one.
+func TemplateSourceChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			switch e.ObjectNew.(type) {
+			case *corev1.ConfigMap, *corev1.Secret:
+				return e.ObjectOld.GetResourceVersion() != e.ObjectNew.GetResourceVersion()
+			default:
+				return false
+			}
+		},
+	}
+}
+
 // UpdateFromLookup creates an updater function that will trigger an update
 // on all resources that are referencing the updated object.
 // The lookupField is the field that is used to lookup the resources.
@@ -168,6 +256,50 @@ func (w *Type) queueByFieldReferencing(
 		return
 	}
 
+	w.queueItems(objectList, q)
+}
+
+// API resources are not reconciled when their templates change,
+// the API definitions referencing them are reconciled instead.
+func (w *Type) queueByResourcesReferencing(
+	field indexer.IndexField,
+	ref refs.NamespacedName,
+	q workqueue.RateLimitingInterface,
+) {
+	switch w.objectList.(type) {
+	case *v1alpha1.ApiDefinitionList, *v1alpha1.ApiV4DefinitionList:
+	default:
+		return
+	}
+
+	resources := &v1alpha1.ApiResourceList{}
+	if err := search.New(w.ctx, w.k8s).FindByFieldReferencing(field, ref, resources); err != nil {
+		log.FromContext(w.ctx).Error(err, "error while searching for API resources referencing", "reference", ref.String())
+		return
+	}
+
+	for i := range resources.Items {
+		resource := &resources.Items[i]
+		w.queueByFieldReferencing(indexer.ResourceField, refs.NewNamespacedName(resource.Namespace, resource.Name), q)
+	}
+}
+
+func (w *Type) queueInNamespace(namespace string, q workqueue.RateLimitingInterface) {
+	objectList, err := list.OfType(w.objectList)
+	if err != nil {
+		log.FromContext(w.ctx).Error(err, "unable to create list of type", "type", w.objectList)
+		return
+	}
+
+	if err = w.k8s.List(w.ctx, objectList, client.InNamespace(namespace)); err != nil {
+		log.FromContext(w.ctx).Error(err, "error while listing items in namespace", "namespace", namespace)
+		return
+	}
+
+	w.queueItems(objectList, q)
+}
+
+func (w *Type) queueItems(objectList client.ObjectList, q workqueue.RateLimitingInterface) {
 	items, err := meta.ExtractList(objectList)
 	if err != nil {
 		log.FromContext(w.ctx).Error(err, "error while extracting list items of type", "type", w.objectList)
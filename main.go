@@ -26,20 +26,26 @@ import (
 
 	"gopkg.in/yaml.v3"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/application"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/secrets"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/subscription"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/templating"
 
 	v1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/admission"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/k8s"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/logging"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/metrics"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/tracing"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/watch"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -51,6 +57,7 @@ import (
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apidefinition"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apiresource"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apiv4definition"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/ingress"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/managementcontext"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -108,15 +115,25 @@ func main() {
 		metricsAddr = "0" // disables metrics
 	}
 
+	if err := checkDeletionPolicy(); err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	if err := checkLocalDefinitionStore(); err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
 	if env.Config.InsecureSkipVerify {
 		setupLog.Info("TLS verification is skipped for APIM HTTP client")
 	}
 
-	metrics := metricsserver.Options{BindAddress: metricsAddr}
+	metricsOptions := metricsserver.Options{BindAddress: metricsAddr}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:  scheme,
-		Metrics: metrics,
+		Metrics: metricsOptions,
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port: managerPort,
 		}),
@@ -145,17 +162,18 @@ func main() {
 
 	registerControllers(mgr)
 
-	//+kubebuilder:scaffold:builder
+	registerTelemetry(mgr)
 
-	if healthCheckErr := mgr.AddHealthzCheck("healthz", healthz.Ping); healthCheckErr != nil {
-		setupLog.Error(healthCheckErr, "unable to set up health check")
-		os.Exit(1)
+	if env.Config.EnableWebhook {
+		if err = admission.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to register admission webhooks")
+			os.Exit(1)
+		}
 	}
 
-	if readyCheckErr := mgr.AddReadyzCheck("readyz", healthz.Ping); readyCheckErr != nil {
-		setupLog.Error(readyCheckErr, "unable to set up ready check")
-		os.Exit(1)
-	}
+	//+kubebuilder:scaffold:builder
+
+	registerHealthChecks(mgr)
 
 	k8s.RegisterClient(mgr.GetClient())
 
@@ -166,6 +184,27 @@ func main() {
 	}
 }
 
+// An unknown deletion policy must not fall back to deleting resources from APIM.
+func checkDeletionPolicy() error {
+	switch gio.DeletionPolicy(env.Config.DeletionPolicy) {
+	case gio.DeletionPolicyDelete, gio.DeletionPolicyRetain, gio.DeletionPolicyOrphan:
+		return nil
+	default:
+		return fmt.Errorf("unknown deletion policy %s, expected one of Delete, Retain or Orphan", env.Config.DeletionPolicy)
+	}
+}
+
+func checkLocalDefinitionStore() error {
+	switch env.Config.LocalDefinitionStore {
+	case env.ConfigMapStore, env.SecretStore:
+		return nil
+	default:
+		return fmt.Errorf(
+			"unknown local definition store %s, expected one of ConfigMap or Secret", env.Config.LocalDefinitionStore,
+		)
+	}
+}
+
 func buildCacheOptions(ns string) cache.Options {
 	if ns == "" {
 		return cache.Options{}
@@ -177,6 +216,48 @@ func buildCacheOptions(ns string) cache.Options {
 	}
 }
 
+func registerHealthChecks(mgr manager.Manager) {
+	if healthCheckErr := mgr.AddHealthzCheck("healthz", healthz.Ping); healthCheckErr != nil {
+		setupLog.Error(healthCheckErr, "unable to set up health check")
+		os.Exit(1)
+	}
+
+	if readyCheckErr := mgr.AddReadyzCheck("readyz", healthz.Ping); readyCheckErr != nil {
+		setupLog.Error(readyCheckErr, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	if env.Config.ContextReadinessCheck {
+		contextCheck := managementcontext.ReadinessCheck(mgr.GetClient())
+		if readyCheckErr := mgr.AddReadyzCheck("contexts", contextCheck); readyCheckErr != nil {
+			setupLog.Error(readyCheckErr, "unable to set up context ready check")
+			os.Exit(1)
+		}
+	}
+}
+
+func registerTelemetry(mgr manager.Manager) {
+	if env.Config.TracingEndpoint != "" {
+		provider, err := tracing.NewProvider(context.Background(), env.Config.TracingServiceName)
+		if err == nil {
+			err = mgr.Add(provider)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		tracing.Register(provider)
+		setupLog.Info("exporting traces", "endpoint", env.Config.TracingEndpoint)
+	}
+
+	if env.Config.EnableMetrics {
+		if err := metrics.RegisterResourceCollector(mgr.GetClient()); err != nil {
+			setupLog.Error(err, "unable to register metrics")
+			os.Exit(1)
+		}
+	}
+}
+
 func registerControllers(mgr manager.Manager) {
 	if err := (&apidefinition.Reconciler{
 		Client:   mgr.GetClient(),
@@ -188,6 +269,16 @@ func registerControllers(mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	if err := (&apiv4definition.Reconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("apiv4definition-controller"),
+		Watcher:  watch.New(context.Background(), mgr.GetClient(), &gio.ApiV4DefinitionList{}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApiV4Definition")
+		os.Exit(1)
+	}
+
 	if err := (&managementcontext.Reconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -223,6 +314,15 @@ func registerControllers(mgr manager.Manager) {
 		setupLog.Error(err, "unable to create controller", "controller", "Application")
 		os.Exit(1)
 	}
+	if err := (&subscription.Reconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("subscription-controller"),
+		Watcher:  watch.New(context.Background(), mgr.GetClient(), &gio.SubscriptionList{}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Subscription")
+		os.Exit(1)
+	}
 
 	if err := (&secrets.Reconciler{
 		Client: mgr.GetClient(),
@@ -231,6 +331,22 @@ func registerControllers(mgr manager.Manager) {
 		setupLog.Error(err, "unable to create controller", "controller", "Secret")
 		os.Exit(1)
 	}
+
+	for _, source := range []templating.Source{templating.ConfigMaps, templating.Secrets} {
+		if err := (&templating.Reconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Source: source,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", source.Kind+"Templating")
+			os.Exit(1)
+		}
+	}
+
+	if err := mgr.Add(templating.NewRefresher(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to set up secret provider refresh")
+		os.Exit(1)
+	}
 }
 
 func addIndexer(mgr manager.Manager) error {
@@ -259,6 +375,21 @@ func addIndexer(mgr manager.Manager) error {
 		return fmt.Errorf("unable to start manager (Indexing fields in application resources)")
 	}
 
+	err = indexSubscriptionFields(mgr)
+	if err != nil {
+		return fmt.Errorf("unable to start manager (Indexing fields in subscription resources)")
+	}
+
+	err = indexApiV4DefinitionFields(mgr)
+	if err != nil {
+		return fmt.Errorf("unable to start manager (Indexing fields in API v4 definition)")
+	}
+
+	err = indexTemplateFields(mgr)
+	if err != nil {
+		return fmt.Errorf("unable to start manager (Indexing templated config maps and secrets)")
+	}
+
 	return nil
 }
 
@@ -328,6 +459,59 @@ func indexApplicationFields(manager ctrl.Manager) error {
 	return nil
 }
 
+func indexApiV4DefinitionFields(manager ctrl.Manager) error {
+	cache := manager.GetCache()
+	ctx := context.Background()
+
+	contextIndexer := indexer.NewIndexer(indexer.ContextField, indexer.IndexApiV4ManagementContexts)
+	err := cache.IndexField(ctx, &gio.ApiV4Definition{}, contextIndexer.Field, contextIndexer.Func)
+	if err != nil {
+		return err
+	}
+
+	resourceIndexer := indexer.NewIndexer(indexer.ResourceField, indexer.IndexApiV4ResourceRefs)
+	err = cache.IndexField(ctx, &gio.ApiV4Definition{}, resourceIndexer.Field, resourceIndexer.Func)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Resources supporting templating are indexed by the config maps, secrets and secret providers
+// they are using, so that they can be reconciled when one of them changes.
+func indexTemplateFields(manager ctrl.Manager) error {
+	cache := manager.GetCache()
+	ctx := context.Background()
+
+	configMapIndexer := indexer.NewIndexer(indexer.TemplateConfigMapField, indexer.IndexTemplateConfigMaps)
+	secretIndexer := indexer.NewIndexer(indexer.TemplateSecretField, indexer.IndexTemplateSecrets)
+	providerIndexer := indexer.NewIndexer(indexer.TemplateProviderField, indexer.IndexTemplateProviders)
+
+	templated := []client.Object{
+		&gio.ApiDefinition{},
+		&gio.ApiV4Definition{},
+		&gio.Application{},
+		&gio.ApiResource{},
+		&gio.ManagementContext{},
+		&v1.Ingress{},
+	}
+
+	for _, obj := range templated {
+		if err := cache.IndexField(ctx, obj, configMapIndexer.Field, configMapIndexer.Func); err != nil {
+			return err
+		}
+		if err := cache.IndexField(ctx, obj, secretIndexer.Field, secretIndexer.Func); err != nil {
+			return err
+		}
+		if err := cache.IndexField(ctx, obj, providerIndexer.Field, providerIndexer.Func); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func applyCRDs() error {
 	client := dynamic.NewForConfigOrDie(ctrl.GetConfigOrDie())
 	ctx := context.Background()
@@ -367,3 +551,22 @@ func applyCRDs() error {
 		return err
 	})
 }
+
+func indexSubscriptionFields(manager ctrl.Manager) error {
+	cache := manager.GetCache()
+	ctx := context.Background()
+
+	apiIndexer := indexer.NewIndexer(indexer.SubscriptionApiField, indexer.IndexSubscriptionApiRefs)
+	err := cache.IndexField(ctx, &gio.Subscription{}, apiIndexer.Field, apiIndexer.Func)
+	if err != nil {
+		return err
+	}
+
+	appIndexer := indexer.NewIndexer(indexer.SubscriptionAppField, indexer.IndexSubscriptionAppRefs)
+	err = cache.IndexField(ctx, &gio.Subscription{}, appIndexer.Field, appIndexer.Func)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
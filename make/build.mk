@@ -14,3 +14,6 @@ manifests: controller-gen ## Generate WebhookConfiguration, ClusterRole and Cust
 generate: controller-gen ## Generate code containing DeepCopy, DeepCopyInto, and DeepCopyObject method implementations.
 	$(CONTROLLER_GEN) object:headerFile="hack/boilerplate.go.txt" paths="./..."
 
+.PHONY: build-export
+build-export: ## Build the export command, turning existing APIM APIs and applications into custom resources.
+	go build -o bin/export ./cmd/export
@@ -33,10 +33,31 @@ const (
 
 	CrdManagementContextResource = "managementcontext"
 	CrdApiDefinitionResource     = "apidefinitions"
+	CrdSubscriptionResource      = "subscriptions"
+	CrdApiV4DefinitionResource   = "apiv4definitions"
 )
 
 const Extends = "gravitee.io/extends"
 
+// AdoptAnnotation holds the ID (or cross ID) of an existing APIM API or application
+// that a custom resource should take ownership of instead of creating a new one.
+const AdoptAnnotation = "gravitee.io/adopt"
+
+// DryRunAnnotation can be set to "true" on an API definition or an application to report
+// what would be applied to APIM in its status and events, without applying it.
+const DryRunAnnotation = "gravitee.io/dry-run"
+
+// DeploymentLabelAnnotation is used by default to label the deployments of an API in APIM.
+const DeploymentLabelAnnotation = "gravitee.io/deployment-label"
+
+// PausedAnnotation can be set to "true" on a resource, a namespace or a management context
+// to stop reconciling the resources it applies to until the annotation is removed.
+const PausedAnnotation = "gravitee.io/paused"
+
+// TemplateReferencesAnnotation can be set on a namespace to the comma separated list of namespaces
+// (or "*" for all of them) allowed to use its config maps and secrets in their templates.
+const TemplateReferencesAnnotation = "gravitee.io/template-references-from"
+
 // Kubernetes Finalizers.
 const (
 	ApiDefinitionDeletionFinalizer = "finalizers.gravitee.io/apidefinitiondeletion"
@@ -49,4 +70,6 @@ const (
 	KeyPairFinalizer                 = "finalizers.gravitee.io/keypair"
 	ApplicationDeletionFinalizer     = "finalizers.gravitee.io/applicationdeletion"
 	TemplatingFinalizer              = "finalizers.gravitee.io/templating"
+	SubscriptionDeletionFinalizer    = "finalizers.gravitee.io/subscriptiondeletion"
+	ApiV4DefinitionDeletionFinalizer = "finalizers.gravitee.io/apiv4definitiondeletion"
 )
@@ -174,5 +174,50 @@ var _ = Describe("API Definition Controller", func() {
 				}, cm)
 			}, timeout, interval).ShouldNot(Succeed())
 		})
+
+		It("Should retain the API in APIM with the Retain deletion policy", func() {
+			createdApiDefinition := new(gio.ApiDefinition)
+			apim, err := internal.NewAPIM(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() error {
+				if err = k8sClient.Get(ctx, apiLookupKey, createdApiDefinition); err != nil {
+					return err
+				}
+				return internal.AssertApiStatusIsSet(createdApiDefinition)
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			By("Set the deletion policy to Retain")
+
+			Eventually(func() error {
+				if err = k8sClient.Get(ctx, apiLookupKey, createdApiDefinition); err != nil {
+					return err
+				}
+				createdApiDefinition.Spec.DeletionPolicy = gio.DeletionPolicyRetain
+				return k8sClient.Update(ctx, createdApiDefinition)
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			Eventually(func() bool {
+				if err = k8sClient.Get(ctx, apiLookupKey, createdApiDefinition); err != nil {
+					return false
+				}
+				return createdApiDefinition.Status.ObservedGeneration == createdApiDefinition.Generation
+			}, timeout, interval).Should(BeTrue())
+
+			By("Delete the API Definition")
+
+			Expect(k8sClient.Delete(ctx, createdApiDefinition)).To(Succeed())
+
+			Eventually(func() error {
+				return k8sClient.Get(ctx, apiLookupKey, createdApiDefinition)
+			}, timeout, interval).ShouldNot(Succeed())
+
+			By("Call rest API and expect the API to still exist")
+
+			_, err = apim.APIs.GetByID(createdApiDefinition.Status.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(apim.APIs.Delete(createdApiDefinition.Status.ID)).To(Succeed())
+		})
 	})
 })
@@ -0,0 +1,95 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"net/http"
+	"time"
+
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/errors"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/test/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("API v4 definition", func() {
+	httpClient := http.Client{Timeout: 5 * time.Second}
+
+	Context("With a proxy API and a management context", func() {
+		var fixtures *internal.Fixtures
+		var apiLookupKey types.NamespacedName
+
+		BeforeEach(func() {
+			By("Initializing the API v4 fixtures")
+			fixtureGenerator := internal.NewFixtureGenerator()
+
+			var err error
+			fixtures, err = fixtureGenerator.NewFixtures(internal.FixtureFiles{
+				ApiV4:   internal.ApiV4ProxyFile,
+				Context: internal.ContextWithCredentialsFile,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			apiLookupKey = types.NamespacedName{Name: fixtures.ApiV4.Name, Namespace: namespace}
+
+			By("Creating the management context")
+			Expect(k8sClient.Create(ctx, fixtures.Context)).Should(Succeed())
+		})
+
+		It("Should import, expose and delete the API", func() {
+			By("Creating the API v4 definition")
+			Expect(k8sClient.Create(ctx, fixtures.ApiV4)).Should(Succeed())
+
+			createdApi := new(gio.ApiV4Definition)
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, apiLookupKey, createdApi); err != nil {
+					return err
+				}
+				return internal.AssertApiV4StatusIsSet(createdApi)
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			By("Calling Management API and expecting the API to be found")
+			apim, err := internal.NewAPIM(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			mgmtApi, err := apim.APIsV4.GetByID(createdApi.Status.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mgmtApi.Name).Should(Equal(fixtures.ApiV4.Spec.Name))
+
+			By("Calling gateway endpoint and expecting the API to be available")
+			endpoint := internal.GatewayUrl + fixtures.ApiV4.Spec.Listeners[0].Paths[0].Path
+			Eventually(func() error {
+				res, callErr := httpClient.Get(endpoint)
+				return internal.AssertNoErrorAndHTTPStatus(callErr, res, http.StatusOK)
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			By("Deleting the API v4 definition")
+			Expect(k8sClient.Delete(ctx, createdApi)).Should(Succeed())
+
+			Eventually(func() error {
+				return client.IgnoreNotFound(k8sClient.Get(ctx, apiLookupKey, new(gio.ApiV4Definition)))
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			By("Calling Management API and expecting the API to be deleted")
+			Eventually(func() bool {
+				_, err = apim.APIsV4.GetByID(createdApi.Status.ID)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+})
@@ -148,7 +148,7 @@ var _ = Describe("Creating an ingress", func() {
 				},
 			))
 
-			cli := xhttp.NewClient(ctx, nil)
+			cli := xhttp.NewClient(ctx, nil, nil)
 
 			By("Checking that rule with host foo.example.com is working")
 
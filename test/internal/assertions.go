@@ -21,6 +21,9 @@ import (
 
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/conditions"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func AssertApplicationStatusIsSet(application *gio.Application) error {
@@ -45,6 +48,54 @@ func AssertApplicationStatusIsSet(application *gio.Application) error {
 	return nil
 }
 
+func AssertSubscriptionStatusIsSet(subscription *gio.Subscription) error {
+	status := subscription.Status
+
+	if status.ID == "" {
+		return fmt.Errorf("id should not be empty in status")
+	}
+
+	if status.PlanID == "" {
+		return fmt.Errorf("planId should not be empty in status")
+	}
+
+	if err := AssertConditionIsTrue(status.Conditions, conditions.Ready); err != nil {
+		return err
+	}
+
+	if status.SubscriptionStatus == "" {
+		return fmt.Errorf("subscriptionStatus should not be empty in status")
+	}
+
+	if status.Status == "" {
+		return fmt.Errorf("status should not be empty in status")
+	}
+
+	return nil
+}
+
+func AssertApiV4StatusIsSet(apiDefinition *gio.ApiV4Definition) error {
+	status := apiDefinition.Status
+
+	if status.ID == "" {
+		return fmt.Errorf("id should not be empty in status")
+	}
+
+	if err := AssertConditionIsTrue(status.Conditions, conditions.Ready); err != nil {
+		return err
+	}
+
+	if len(status.Plans) != len(apiDefinition.Spec.Plans) {
+		return fmt.Errorf("expected %d plans in status, got %d", len(apiDefinition.Spec.Plans), len(status.Plans))
+	}
+
+	if status.Status != gio.ProcessingStatusCompleted {
+		return fmt.Errorf("expected status %s, got %s", gio.ProcessingStatusCompleted, status.Status)
+	}
+
+	return nil
+}
+
 func AssertApiStatusIsSet(apiDefinition *gio.ApiDefinition) error {
 	status := apiDefinition.Status
 
@@ -112,6 +163,14 @@ func AssertNoErrorAndObservedGenerationEquals(
 	return nil
 }
 
+func AssertConditionIsTrue(conds []metav1.Condition, conditionType string) error {
+	if !meta.IsStatusConditionTrue(conds, conditionType) {
+		condition := meta.FindStatusCondition(conds, conditionType)
+		return fmt.Errorf("expected condition %s to be true, got %+v", conditionType, condition)
+	}
+	return nil
+}
+
 func AssertEquals(property string, expected, actual interface{}) error {
 	if !reflect.DeepEqual(expected, actual) {
 		return NewAssertionError(property, expected, actual)
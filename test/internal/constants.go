@@ -31,6 +31,7 @@ const (
 	BasicApiFileTemplating              = SamplesPath + "/apim/basic-api-templating.yml"
 	ExportedApi                         = SamplesPath + "/apim/exported-api.yml"
 	ApiWithContextFile                  = SamplesPath + "/apim/api-with-context.yml"
+	ApiV4ProxyFile                      = SamplesPath + "/apim/api-v4-proxy.yml"
 	ApiWithContextNoPlanFile            = SamplesPath + "/apim/api-with-no-plan.yml"
 	ApiWithDisabledHCFile               = SamplesPath + "/apim/api-with-health-check-disabled.yml"
 	ApiWithHCFile                       = SamplesPath + "/apim/api-with-health-check.yml"
@@ -69,4 +70,5 @@ const (
 	IngressWithMultipleHosts            = SamplesPath + "/ingress/ingress-with-multiple-hosts.yml"
 	IngressWithTLS                      = SamplesPath + "/ingress/ingress-with-tls.yml"
 	BasicApplication                    = SamplesPath + "/apim/basic-application.yml"
+	BasicSubscription                   = SamplesPath + "/apim/basic-subscription.yml"
 )
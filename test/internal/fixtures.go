@@ -33,19 +33,23 @@ import (
 var decode = scheme.Codecs.UniversalDecoder().Decode
 
 type Fixtures struct {
-	Api         *gio.ApiDefinition
-	Context     *gio.ManagementContext
-	Resource    *gio.ApiResource
-	Ingress     *netV1.Ingress
-	Application *gio.Application
+	Api          *gio.ApiDefinition
+	Context      *gio.ManagementContext
+	Resource     *gio.ApiResource
+	Ingress      *netV1.Ingress
+	Application  *gio.Application
+	Subscription *gio.Subscription
+	ApiV4        *gio.ApiV4Definition
 }
 
 type FixtureFiles struct {
-	Api         string
-	Context     string
-	Resource    string
-	Ingress     string
-	Application string
+	Api          string
+	Context      string
+	Resource     string
+	Ingress      string
+	Application  string
+	Subscription string
+	ApiV4        string
 }
 
 type FixtureGenerator struct {
@@ -118,6 +122,16 @@ func (f *FixtureGenerator) NewFixtures(files FixtureFiles, transforms ...func(*F
 		return nil, err
 	}
 
+	err = f.addSubscription(files, fixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.addApiV4(files, fixtures)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, transform := range transforms {
 		transform(fixtures)
 	}
@@ -141,6 +155,48 @@ func (f *FixtureGenerator) addApplication(files FixtureFiles, fixtures *Fixtures
 	return nil
 }
 
+func (f *FixtureGenerator) addSubscription(files FixtureFiles, fixtures *Fixtures) error {
+	if files.Subscription != "" {
+		subscription, err := f.NewSubscription(files.Subscription)
+		if err != nil {
+			return err
+		}
+		fixtures.Subscription = subscription
+	}
+
+	if fixtures.Subscription == nil {
+		return nil
+	}
+
+	if fixtures.Api != nil {
+		fixtures.Subscription.Spec.API = refs.NewNamespacedName(fixtures.Api.Namespace, fixtures.Api.Name)
+	}
+
+	if fixtures.Application != nil {
+		fixtures.Subscription.Spec.App = refs.NewNamespacedName(
+			fixtures.Application.Namespace, fixtures.Application.Name,
+		)
+	}
+
+	return nil
+}
+
+func (f *FixtureGenerator) addApiV4(files FixtureFiles, fixtures *Fixtures) error {
+	if files.ApiV4 != "" {
+		api, err := f.NewApiV4Definition(files.ApiV4)
+		if err != nil {
+			return err
+		}
+		fixtures.ApiV4 = api
+	}
+
+	if fixtures.Context != nil && fixtures.ApiV4 != nil {
+		fixtures.ApiV4.Spec.Context = fixtures.Context.GetNamespacedName()
+	}
+
+	return nil
+}
+
 func ingressHttpPathTransformer(f *FixtureGenerator) func(ingress *netV1.Ingress) {
 	return func(ingress *netV1.Ingress) {
 		for i := range ingress.Spec.Rules {
@@ -336,6 +392,86 @@ func newApplication(path string, transforms ...func(application *gio.Application
 	return application, nil
 }
 
+func (f *FixtureGenerator) NewSubscription(path string,
+	transforms ...func(subscription *gio.Subscription)) (*gio.Subscription, error) {
+	subscription, err := newSubscription(path, transforms...)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Name += f.Suffix
+
+	return subscription, nil
+}
+
+func newSubscription(path string, transforms ...func(subscription *gio.Subscription)) (*gio.Subscription, error) {
+	crd, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := gio.GroupVersion.WithKind("Subscription")
+	decoded, _, err := decode(crd, &gvk, new(gio.Subscription))
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, ok := decoded.(*gio.Subscription)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type of Subscription CRD")
+	}
+
+	for _, transform := range transforms {
+		transform(subscription)
+	}
+
+	return subscription, nil
+}
+
+func (f *FixtureGenerator) NewApiV4Definition(
+	path string, transforms ...func(*gio.ApiV4Definition),
+) (*gio.ApiV4Definition, error) {
+	api, err := newApiV4Definition(path, transforms...)
+	if err != nil {
+		return nil, err
+	}
+
+	api.Name += f.Suffix
+	api.Namespace = Namespace
+	api.Spec.Name += f.Suffix
+
+	for _, listener := range api.Spec.Listeners {
+		for _, path := range listener.Paths {
+			path.Path += f.Suffix
+		}
+	}
+
+	return api, nil
+}
+
+func newApiV4Definition(path string, transforms ...func(*gio.ApiV4Definition)) (*gio.ApiV4Definition, error) {
+	crd, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := gio.GroupVersion.WithKind("ApiV4Definition")
+	decoded, _, err := decode(crd, &gvk, new(gio.ApiV4Definition))
+	if err != nil {
+		return nil, err
+	}
+
+	api, ok := decoded.(*gio.ApiV4Definition)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type of API v4 CRD")
+	}
+
+	for _, transform := range transforms {
+		transform(api)
+	}
+
+	return api, nil
+}
+
 func randomSuffix() string {
 	return "-" + uuid.NewV4().String()[:7]
 }
@@ -0,0 +1,108 @@
+// Copyright (C) 2015 The Gravitee team (http://gravitee.io)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/apim/model"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/test/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Subscribing an Application to an API", func() {
+	Context("With an API key plan", func() {
+		var fixtures *internal.Fixtures
+		var subscriptionLookupKey types.NamespacedName
+
+		BeforeEach(func() {
+			By("Initializing the subscription fixtures")
+			fixtureGenerator := internal.NewFixtureGenerator()
+
+			var err error
+			fixtures, err = fixtureGenerator.NewFixtures(internal.FixtureFiles{
+				Api:          internal.ApiWithApiKeyPlanFile,
+				Application:  internal.BasicApplication,
+				Subscription: internal.BasicSubscription,
+				Context:      internal.ContextWithCredentialsFile,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			fixtures.Application.Spec.Name += fixtureGenerator.Suffix
+			subscriptionLookupKey = types.NamespacedName{Name: fixtures.Subscription.Name, Namespace: namespace}
+
+			By("Creating the management context, API and application")
+			Expect(k8sClient.Create(ctx, fixtures.Context)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, fixtures.Api)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, fixtures.Application)).Should(Succeed())
+		})
+
+		It("Should create and close the subscription in APIM", func() {
+			By("Creating the subscription")
+			Expect(k8sClient.Create(ctx, fixtures.Subscription)).Should(Succeed())
+
+			createdSubscription := new(gio.Subscription)
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, subscriptionLookupKey, createdSubscription); err != nil {
+					return err
+				}
+				return internal.AssertSubscriptionStatusIsSet(createdSubscription)
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			By("Calling Management API and expecting the subscription to be accepted")
+			apim, err := internal.NewAPIM(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			status := createdSubscription.Status
+			mgmtSubscription, err := apim.Subscriptions.GetByID(status.ApiID, status.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mgmtSubscription.Status).Should(Equal(model.SubscriptionStatusAccepted))
+			Expect(mgmtSubscription.PlanID()).Should(Equal(status.PlanID))
+
+			By("Expecting the API key of the subscription to be written into a secret")
+			apiKeys, err := apim.Subscriptions.GetApiKeys(status.ApiID, status.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apiKeys).ToNot(BeEmpty())
+
+			secret := new(coreV1.Secret)
+			secretLookupKey := types.NamespacedName{Name: fixtures.Subscription.GetApiKeySecretName(), Namespace: namespace}
+			Eventually(func() error {
+				if err = k8sClient.Get(ctx, secretLookupKey, secret); err != nil {
+					return err
+				}
+				return internal.AssertEquals("apiKey", apiKeys[0].Key, string(secret.Data["apiKey"]))
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			By("Deleting the subscription")
+			Expect(k8sClient.Delete(ctx, createdSubscription)).Should(Succeed())
+
+			Eventually(func() error {
+				return client.IgnoreNotFound(k8sClient.Get(ctx, subscriptionLookupKey, new(gio.Subscription)))
+			}, timeout, interval).ShouldNot(HaveOccurred())
+
+			By("Calling Management API and expecting the subscription to be closed")
+			Eventually(func() error {
+				mgmtSubscription, err = apim.Subscriptions.GetByID(status.ApiID, status.ID)
+				if err != nil {
+					return err
+				}
+				return internal.AssertEquals("status", model.SubscriptionStatusClosed, mgmtSubscription.Status)
+			}, timeout, interval).ShouldNot(HaveOccurred())
+		})
+	})
+})
@@ -21,6 +21,7 @@ import (
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/application"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/secrets"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/subscription"
 
 	"github.com/gravitee-io/gravitee-kubernetes-operator/pkg/keys"
 
@@ -45,6 +46,7 @@ import (
 	gio "github.com/gravitee-io/gravitee-kubernetes-operator/api/v1alpha1"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apidefinition"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apiresource"
+	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/apiv4definition"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/controllers/apim/managementcontext"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/env"
 	"github.com/gravitee-io/gravitee-kubernetes-operator/internal/indexer"
@@ -113,6 +115,15 @@ var _ = SynchronizedBeforeSuite(func() {
 
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&apiv4definition.Reconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("apiv4definition-controller"),
+		Watcher:  watch.New(context.Background(), k8sManager.GetClient(), &gio.ApiV4DefinitionList{}),
+	}).SetupWithManager(k8sManager)
+
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&managementcontext.Reconciler{
 		Client:   k8sManager.GetClient(),
 		Scheme:   k8sManager.GetScheme(),
@@ -148,6 +159,15 @@ var _ = SynchronizedBeforeSuite(func() {
 
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&subscription.Reconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("subscription-controller"),
+		Watcher:  watch.New(context.Background(), k8sManager.GetClient(), &gio.SubscriptionList{}),
+	}).SetupWithManager(k8sManager)
+
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&secrets.Reconciler{
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
@@ -185,6 +205,22 @@ var _ = SynchronizedBeforeSuite(func() {
 	err = cache.IndexField(ctx, &gio.Application{}, appContextIndexer.Field, appContextIndexer.Func)
 	Expect(err).ToNot(HaveOccurred())
 
+	subscriptionApiIndexer := indexer.NewIndexer(indexer.SubscriptionApiField, indexer.IndexSubscriptionApiRefs)
+	err = cache.IndexField(ctx, &gio.Subscription{}, subscriptionApiIndexer.Field, subscriptionApiIndexer.Func)
+	Expect(err).ToNot(HaveOccurred())
+
+	subscriptionAppIndexer := indexer.NewIndexer(indexer.SubscriptionAppField, indexer.IndexSubscriptionAppRefs)
+	err = cache.IndexField(ctx, &gio.Subscription{}, subscriptionAppIndexer.Field, subscriptionAppIndexer.Func)
+	Expect(err).ToNot(HaveOccurred())
+
+	apiV4ContextIndexer := indexer.NewIndexer(indexer.ContextField, indexer.IndexApiV4ManagementContexts)
+	err = cache.IndexField(ctx, &gio.ApiV4Definition{}, apiV4ContextIndexer.Field, apiV4ContextIndexer.Func)
+	Expect(err).ToNot(HaveOccurred())
+
+	apiV4ResourceIndexer := indexer.NewIndexer(indexer.ResourceField, indexer.IndexApiV4ResourceRefs)
+	err = cache.IndexField(ctx, &gio.ApiV4Definition{}, apiV4ResourceIndexer.Field, apiV4ResourceIndexer.Func)
+	Expect(err).ToNot(HaveOccurred())
+
 	k8s.RegisterClient(k8sManager.GetClient())
 
 	go func() {